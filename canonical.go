@@ -0,0 +1,583 @@
+package youyouayedee
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// NameCanonicalizer converts a name into its canonical form before hashing, so
+// that equivalent spellings of the same name produce the same UUID.
+type NameCanonicalizer func(name string) (string, error)
+
+// CanonicalizerForNamespace returns the NameCanonicalizer for one of the
+// well-known namespaces, or nil if ns is not one of them.
+//
+//   - NamespaceDNS: CanonicalizeDNS
+//   - NamespaceURL: CanonicalizeURL
+//   - NamespaceOID: CanonicalizeOID
+//   - NamespaceX500: CanonicalizeX500
+//
+func CanonicalizerForNamespace(ns UUID) NameCanonicalizer {
+	switch ns {
+	case NamespaceDNS:
+		return CanonicalizeDNS
+	case NamespaceURL:
+		return CanonicalizeURL
+	case NamespaceOID:
+		return CanonicalizeOID
+	case NamespaceX500:
+		return CanonicalizeX500
+	}
+	return nil
+}
+
+// CanonicalizeDNS returns the canonical form of a fully qualified domain name.
+//
+// The trailing dot, if any, is removed, and ASCII letters are converted to
+// lower case.  Labels containing non-ASCII characters, and A-labels
+// ("xn--..."), are processed with the IDNA Lookup profile, which applies the
+// UTS #46 mapping and NFC normalization, rejects labels which are not valid
+// under IDNA2008, and encodes the result as an A-label.
+//
+// ASCII labels may contain only letters, digits, hyphens, and underscores.
+// Empty labels, labels longer than 63 bytes, and names longer than 253 bytes
+// are rejected.
+//
+func CanonicalizeDNS(name string) (string, error) {
+	fail := func(message string) (string, error) {
+		return "", ErrNameNotValid{Kind: NameDNS, Name: name, Message: message}
+	}
+
+	str := strings.TrimSuffix(name, ".")
+	if str == "" {
+		return fail("name is empty")
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for index, label := range strings.Split(str, ".") {
+		if label == "" {
+			return fail("name contains an empty label")
+		}
+
+		if !isASCII(label) || hasACEPrefix(label) {
+			if !utf8.ValidString(label) {
+				return fail("name is not valid UTF-8")
+			}
+			ascii, err := idna.Lookup.ToASCII(label)
+			if err != nil {
+				return fail("label " + label + " is not a valid IDNA label: " + err.Error())
+			}
+			label = ascii
+		}
+
+		if index > 0 {
+			buf.WriteByte('.')
+		}
+		start := buf.Len()
+		for ii := 0; ii < len(label); ii++ {
+			ch := label[ii]
+			switch {
+			case ch >= 'A' && ch <= 'Z':
+				ch += 'a' - 'A'
+			case ch >= 'a' && ch <= 'z':
+			case ch >= '0' && ch <= '9':
+			case ch == '-' || ch == '_':
+			default:
+				return fail("label " + label + " contains invalid characters")
+			}
+			buf.WriteByte(ch)
+		}
+		if buf.Len()-start > 63 {
+			return fail("label " + label + " is longer than 63 bytes")
+		}
+	}
+
+	if buf.Len() > 253 {
+		return fail("name is longer than 253 bytes")
+	}
+	return buf.String(), nil
+}
+
+// CanonicalizeURL returns the canonical form of an absolute URL, by applying
+// the normalizations of RFC 3986 Section 6.2.2 and a few of Section 6.2.3:
+//
+//   - the scheme is converted to lower case
+//   - the host is canonicalized with CanonicalizeDNS, unless it is an IP
+//     literal in brackets, which is only converted to lower case
+//   - the default port for http, https, ws, wss, and ftp is removed
+//   - an empty path is replaced by "/" when there is an authority
+//   - "." and ".." path segments are removed
+//   - percent-encoded unreserved characters are decoded, other percent
+//     encodings use upper case hex digits, and bytes which may not appear
+//     literally in a URL are percent-encoded
+//
+func CanonicalizeURL(rawURL string) (string, error) {
+	fail := func(message string) (string, error) {
+		return "", ErrNameNotValid{Kind: NameURL, Name: rawURL, Message: message}
+	}
+
+	colon := strings.IndexByte(rawURL, ':')
+	if colon <= 0 || !isURLScheme(rawURL[:colon]) {
+		return fail("URL has no valid scheme")
+	}
+	scheme := strings.ToLower(rawURL[:colon])
+	rest := rawURL[colon+1:]
+
+	var fragment, query string
+	hasFragment, hasQuery := false, false
+	if index := strings.IndexByte(rest, '#'); index >= 0 {
+		rest, fragment, hasFragment = rest[:index], rest[index+1:], true
+	}
+	if index := strings.IndexByte(rest, '?'); index >= 0 {
+		rest, query, hasQuery = rest[:index], rest[index+1:], true
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(rawURL))
+	buf.WriteString(scheme)
+	buf.WriteByte(':')
+
+	path := rest
+	hasAuthority := strings.HasPrefix(rest, "//")
+	if hasAuthority {
+		authority := rest[2:]
+		path = ""
+		if index := strings.IndexByte(authority, '/'); index >= 0 {
+			authority, path = authority[:index], authority[index:]
+		}
+
+		var userinfo string
+		hasUserinfo := false
+		if index := strings.LastIndexByte(authority, '@'); index >= 0 {
+			userinfo, authority, hasUserinfo = authority[:index], authority[index+1:], true
+		}
+
+		host, port := authority, ""
+		if index := strings.LastIndexByte(authority, ':'); index >= 0 && !strings.Contains(authority[index:], "]") {
+			host, port = authority[:index], authority[index+1:]
+		}
+		for ii := 0; ii < len(port); ii++ {
+			if port[ii] < '0' || port[ii] > '9' {
+				return fail("port " + port + " is not a number")
+			}
+		}
+		for len(port) > 1 && port[0] == '0' {
+			port = port[1:]
+		}
+		if port == urlDefaultPort(scheme) {
+			port = ""
+		}
+
+		switch {
+		case strings.HasPrefix(host, "["):
+			if !strings.HasSuffix(host, "]") {
+				return fail("IP literal is missing closing bracket")
+			}
+			host = strings.ToLower(host)
+		case host != "":
+			decoded, problem := normalizePercent(host)
+			if problem != "" {
+				return fail(problem)
+			}
+			decoded = unescapeNonASCII(decoded)
+			if strings.IndexByte(decoded, '%') < 0 {
+				var err error
+				decoded, err = CanonicalizeDNS(decoded)
+				if err != nil {
+					var nnv ErrNameNotValid
+					if !errors.As(err, &nnv) {
+						return "", err
+					}
+					return fail("host: " + nnv.Message)
+				}
+			}
+			host = decoded
+		}
+
+		buf.WriteString("//")
+		if hasUserinfo {
+			normalized, problem := normalizePercent(userinfo)
+			if problem != "" {
+				return fail(problem)
+			}
+			buf.WriteString(normalized)
+			buf.WriteByte('@')
+		}
+		buf.WriteString(host)
+		if port != "" {
+			buf.WriteByte(':')
+			buf.WriteString(port)
+		}
+	}
+
+	normalized, problem := normalizePercent(path)
+	if problem != "" {
+		return fail(problem)
+	}
+	path = removeDotSegments(normalized)
+	if hasAuthority && path == "" {
+		path = "/"
+	}
+	buf.WriteString(path)
+
+	if hasQuery {
+		normalized, problem := normalizePercent(query)
+		if problem != "" {
+			return fail(problem)
+		}
+		buf.WriteByte('?')
+		buf.WriteString(normalized)
+	}
+	if hasFragment {
+		normalized, problem := normalizePercent(fragment)
+		if problem != "" {
+			return fail(problem)
+		}
+		buf.WriteByte('#')
+		buf.WriteString(normalized)
+	}
+	return buf.String(), nil
+}
+
+// CanonicalizeOID returns the canonical dotted decimal form of an ISO object
+// identifier, such as "1.3.6.1".  A "urn:oid:" prefix and leading zeros are
+// removed.  The OID must have at least two arcs, the first arc must be 0, 1,
+// or 2, and if the first arc is 0 or 1 then the second arc must be at most 39.
+//
+func CanonicalizeOID(oid string) (string, error) {
+	fail := func(message string) (string, error) {
+		return "", ErrNameNotValid{Kind: NameOID, Name: oid, Message: message}
+	}
+
+	str := oid
+	if len(str) >= 8 && strings.EqualFold(str[:8], "urn:oid:") {
+		str = str[8:]
+	}
+
+	arcs := strings.Split(str, ".")
+	if len(arcs) < 2 {
+		return fail("OID must have at least two arcs")
+	}
+	for index, arc := range arcs {
+		if arc == "" {
+			return fail("OID contains an empty arc")
+		}
+		for ii := 0; ii < len(arc); ii++ {
+			if arc[ii] < '0' || arc[ii] > '9' {
+				return fail("arc " + arc + " is not a decimal number")
+			}
+		}
+		arc = strings.TrimLeft(arc, "0")
+		if arc == "" {
+			arc = "0"
+		}
+		arcs[index] = arc
+	}
+
+	switch arcs[0] {
+	case "0", "1":
+		if len(arcs[1]) > 2 || (len(arcs[1]) == 2 && arcs[1] > "39") {
+			return fail("second arc must be at most 39 when the first arc is " + arcs[0])
+		}
+	case "2":
+		// pass
+	default:
+		return fail("first arc must be 0, 1, or 2")
+	}
+	return strings.Join(arcs, "."), nil
+}
+
+// CanonicalizeX500 returns the canonical form of an X.500 distinguished name
+// in the string representation of RFC 4514, such as "CN=Jane Doe,O=Example".
+//
+// Attribute types and values are converted to lower case, since nearly all
+// naming attributes use case-insensitive matching.  Insignificant spaces are
+// removed, runs of spaces within values are collapsed, escapes are decoded and
+// then re-applied only where RFC 4514 requires them, and the values of each
+// multi-valued RDN are sorted.  Values in "#" hex form are kept as-is apart
+// from case.
+//
+func CanonicalizeX500(dn string) (string, error) {
+	fail := func(message string) (string, error) {
+		return "", ErrNameNotValid{Kind: NameX500, Name: dn, Message: message}
+	}
+
+	if strings.TrimSpace(dn) == "" {
+		return fail("name is empty")
+	}
+
+	rdns, problem := splitEscaped(dn, ',')
+	if problem != "" {
+		return fail(problem)
+	}
+
+	out := make([]string, len(rdns))
+	for rdnIndex, rdn := range rdns {
+		avas, problem := splitEscaped(rdn, '+')
+		if problem != "" {
+			return fail(problem)
+		}
+		for avaIndex, ava := range avas {
+			eq := strings.IndexByte(ava, '=')
+			if eq < 0 {
+				return fail("attribute " + strings.TrimSpace(ava) + " has no value")
+			}
+			attrType := strings.ToLower(strings.TrimSpace(ava[:eq]))
+			if attrType == "" {
+				return fail("attribute type is empty")
+			}
+			value, problem := canonicalizeX500Value(ava[eq+1:])
+			if problem != "" {
+				return fail(problem)
+			}
+			avas[avaIndex] = attrType + "=" + value
+		}
+		sort.Strings(avas)
+		out[rdnIndex] = strings.Join(avas, "+")
+	}
+	return strings.Join(out, ","), nil
+}
+
+func hasACEPrefix(label string) bool {
+	return len(label) >= 4 && strings.EqualFold(label[:4], "xn--")
+}
+
+func isASCII(str string) bool {
+	for ii := 0; ii < len(str); ii++ {
+		if str[ii] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func isURLScheme(str string) bool {
+	for ii := 0; ii < len(str); ii++ {
+		ch := str[ii]
+		switch {
+		case (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z'):
+		case ii > 0 && ((ch >= '0' && ch <= '9') || ch == '+' || ch == '-' || ch == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func urlDefaultPort(scheme string) string {
+	switch scheme {
+	case "http", "ws":
+		return "80"
+	case "https", "wss":
+		return "443"
+	case "ftp":
+		return "21"
+	}
+	return ""
+}
+
+func isURLUnreserved(ch byte) bool {
+	return (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '-' || ch == '.' || ch == '_' || ch == '~'
+}
+
+const upperHex = "0123456789ABCDEF"
+
+// normalizePercent decodes percent-encoded unreserved characters, converts the
+// hex digits of other percent encodings to upper case, and percent-encodes
+// spaces, control characters, and non-ASCII bytes.  On failure, it returns a
+// description of the problem.
+func normalizePercent(str string) (string, string) {
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for ii := 0; ii < len(str); ii++ {
+		ch := str[ii]
+		switch {
+		case ch == '%':
+			if ii+2 >= len(str) {
+				return "", "truncated percent encoding"
+			}
+			hi, lo := hexDecode[str[ii+1]], hexDecode[str[ii+2]]
+			if hi >= 0x10 || lo >= 0x10 {
+				return "", "invalid percent encoding " + str[ii:ii+3]
+			}
+			value := (hi << 4) | lo
+			if isURLUnreserved(value) {
+				buf.WriteByte(value)
+			} else {
+				buf.WriteByte('%')
+				buf.WriteByte(upperHex[value>>4])
+				buf.WriteByte(upperHex[value&0x0f])
+			}
+			ii += 2
+		case ch <= 0x20 || ch >= 0x7f:
+			buf.WriteByte('%')
+			buf.WriteByte(upperHex[ch>>4])
+			buf.WriteByte(upperHex[ch&0x0f])
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	return buf.String(), ""
+}
+
+// unescapeNonASCII reverses the percent-encoding of non-ASCII bytes applied by
+// normalizePercent, so that an internationalized host name can be passed to
+// CanonicalizeDNS.
+func unescapeNonASCII(str string) string {
+	if strings.IndexByte(str, '%') < 0 {
+		return str
+	}
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for ii := 0; ii < len(str); ii++ {
+		if str[ii] == '%' && ii+2 < len(str) {
+			hi, lo := hexDecode[str[ii+1]], hexDecode[str[ii+2]]
+			if value := (hi << 4) | lo; hi < 0x10 && lo < 0x10 && value >= 0x80 {
+				buf.WriteByte(value)
+				ii += 2
+				continue
+			}
+		}
+		buf.WriteByte(str[ii])
+	}
+	return buf.String()
+}
+
+// removeDotSegments implements RFC 3986 Section 5.2.4.
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+
+	var out []string
+	in := path
+	for in != "" {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"):
+			in = in[2:]
+		case strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			if len(out) != 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "/..":
+			in = "/"
+			if len(out) != 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "." || in == "..":
+			in = ""
+		default:
+			start := 0
+			if in[0] == '/' {
+				start = 1
+			}
+			end := strings.IndexByte(in[start:], '/')
+			if end < 0 {
+				end = len(in)
+			} else {
+				end += start
+			}
+			out = append(out, in[:end])
+			in = in[end:]
+		}
+	}
+	return strings.Join(out, "")
+}
+
+// splitEscaped splits an RFC 4514 string on every occurrence of sep which is
+// not escaped with a backslash.  On failure, it returns a description of the
+// problem.
+func splitEscaped(str string, sep byte) ([]string, string) {
+	var out []string
+	start := 0
+	for ii := 0; ii < len(str); ii++ {
+		switch str[ii] {
+		case '\\':
+			if ii+1 >= len(str) {
+				return nil, "trailing backslash"
+			}
+			ii++
+		case sep:
+			out = append(out, str[start:ii])
+			start = ii + 1
+		}
+	}
+	return append(out, str[start:]), ""
+}
+
+func canonicalizeX500Value(raw string) (string, string) {
+	// Leading spaces are never significant.  A trailing space is only
+	// significant if it is escaped.
+	raw = strings.TrimLeft(raw, " ")
+	for strings.HasSuffix(raw, " ") && !strings.HasSuffix(raw, "\\ ") {
+		raw = raw[:len(raw)-1]
+	}
+
+	if strings.HasPrefix(raw, "#") {
+		for ii := 1; ii < len(raw); ii++ {
+			if hexDecode[raw[ii]] >= 0x10 {
+				return "", "invalid hex string value"
+			}
+		}
+		return strings.ToLower(raw), ""
+	}
+
+	var decoded []byte
+	for ii := 0; ii < len(raw); ii++ {
+		ch := raw[ii]
+		if ch != '\\' {
+			decoded = append(decoded, ch)
+			continue
+		}
+		ii++
+		if ii < len(raw) && hexDecode[raw[ii]] < 0x10 {
+			if ii+1 >= len(raw) || hexDecode[raw[ii+1]] >= 0x10 {
+				return "", "invalid hex escape"
+			}
+			decoded = append(decoded, (hexDecode[raw[ii]]<<4)|hexDecode[raw[ii+1]])
+			ii++
+			continue
+		}
+		decoded = append(decoded, raw[ii])
+	}
+	if !utf8.Valid(decoded) {
+		return "", "value is not valid UTF-8"
+	}
+
+	var words []string
+	for _, word := range strings.Split(strings.ToLower(string(decoded)), " ") {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	value := strings.Join(words, " ")
+
+	var buf strings.Builder
+	buf.Grow(len(value))
+	for ii := 0; ii < len(value); ii++ {
+		ch := value[ii]
+		switch {
+		case ch == 0:
+			buf.WriteString("\\00")
+			continue
+		case strings.IndexByte("\"+,;<>\\", ch) >= 0:
+			buf.WriteByte('\\')
+		case ii == 0 && ch == '#':
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(ch)
+	}
+	return buf.String(), ""
+}
@@ -0,0 +1,102 @@
+package youyouayedee
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	type testRow struct {
+		Name   string
+		Fn     NameCanonicalizer
+		Input  string
+		Expect string
+		Err    string
+	}
+
+	testData := [...]testRow{
+		{"DNS/lower", CanonicalizeDNS, "Example.COM", "example.com", ""},
+		{"DNS/trailing-dot", CanonicalizeDNS, "example.com.", "example.com", ""},
+		{"DNS/srv", CanonicalizeDNS, "_sip._TCP.example.com", "_sip._tcp.example.com", ""},
+		{"DNS/idna", CanonicalizeDNS, "Bücher.example", "xn--bcher-kva.example", ""},
+		{"DNS/idna-cjk", CanonicalizeDNS, "例え.テスト", "xn--r8jz45g.xn--zckzah", ""},
+		{"DNS/idna-upper", CanonicalizeDNS, "BÜCHER.example", "xn--bcher-kva.example", ""},
+		{"DNS/idna-nfd", CanonicalizeDNS, "Bu\u0308cher.example", "xn--bcher-kva.example", ""},
+		{"DNS/idna-fullwidth", CanonicalizeDNS, "\uff45\uff58\uff41\uff4d\uff50\uff4c\uff45.com", "example.com", ""},
+		{"DNS/idna-invalid", CanonicalizeDNS, "\u0301b.example", "", "label \u0301b is not a valid IDNA label: idna: invalid label \"\u0301b\""},
+		{"DNS/a-label", CanonicalizeDNS, "XN--BCHER-KVA.example", "xn--bcher-kva.example", ""},
+		{"DNS/empty", CanonicalizeDNS, ".", "", "name is empty"},
+		{"DNS/empty-label", CanonicalizeDNS, "a..b", "", "name contains an empty label"},
+		{"DNS/invalid", CanonicalizeDNS, "a b.com", "", "label a b contains invalid characters"},
+
+		{"URL/case", CanonicalizeURL, "HTTP://Example.COM/a/%7euser/%3f", "http://example.com/a/~user/%3F", ""},
+		{"URL/port", CanonicalizeURL, "https://example.com:443", "https://example.com/", ""},
+		{"URL/other-port", CanonicalizeURL, "https://example.com:08443/", "https://example.com:8443/", ""},
+		{"URL/zero-port", CanonicalizeURL, "http://example.com:00/", "http://example.com:0/", ""},
+		{"URL/dots", CanonicalizeURL, "http://example.com/a/./b/../c/", "http://example.com/a/c/", ""},
+		{"URL/query", CanonicalizeURL, "http://example.com/?q=a b#Frag%2d", "http://example.com/?q=a%20b#Frag-", ""},
+		{"URL/userinfo", CanonicalizeURL, "ftp://User@Example.com:21/x", "ftp://User@example.com/x", ""},
+		{"URL/ipv6", CanonicalizeURL, "http://[FE80::1]:80/", "http://[fe80::1]/", ""},
+		{"URL/idna", CanonicalizeURL, "http://bücher.example/", "http://xn--bcher-kva.example/", ""},
+		{"URL/urn", CanonicalizeURL, "URN:ISBN:0451450523", "urn:ISBN:0451450523", ""},
+		{"URL/no-scheme", CanonicalizeURL, "example.com/x", "", "URL has no valid scheme"},
+		{"URL/bad-percent", CanonicalizeURL, "http://example.com/%zz", "", "invalid percent encoding %zz"},
+		{"URL/truncated-percent", CanonicalizeURL, "http://example.com/%4", "", "truncated percent encoding"},
+
+		{"OID/plain", CanonicalizeOID, "1.3.6.1.4.1", "1.3.6.1.4.1", ""},
+		{"OID/urn", CanonicalizeOID, "urn:oid:2.25.0329800735698586629295641978511506172918", "2.25.329800735698586629295641978511506172918", ""},
+		{"OID/zeros", CanonicalizeOID, "1.03.006", "1.3.6", ""},
+		{"OID/one-arc", CanonicalizeOID, "1", "", "OID must have at least two arcs"},
+		{"OID/first-arc", CanonicalizeOID, "3.1", "", "first arc must be 0, 1, or 2"},
+		{"OID/second-arc", CanonicalizeOID, "1.40", "", "second arc must be at most 39 when the first arc is 1"},
+		{"OID/not-number", CanonicalizeOID, "1.3.x", "", "arc x is not a decimal number"},
+
+		{"X500/basic", CanonicalizeX500, "CN=Jane  Doe, O=Example Corp ,C=US", "cn=jane doe,o=example corp,c=us", ""},
+		{"X500/multi", CanonicalizeX500, "OU=Sales+CN=J. Smith,DC=example,DC=net", "cn=j. smith+ou=sales,dc=example,dc=net", ""},
+		{"X500/escapes", CanonicalizeX500, `CN=Before\0DAfter,O=Test\2C Inc.,CN=\#x\ `, "cn=before\rafter,o=test\\, inc.,cn=\\#x", ""},
+		{"X500/hex", CanonicalizeX500, "1.3.6.1.4.1.1466.0=#04024869", "1.3.6.1.4.1.1466.0=#04024869", ""},
+		{"X500/no-value", CanonicalizeX500, "CN", "", "attribute CN has no value"},
+		{"X500/empty", CanonicalizeX500, " ", "", "name is empty"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual, err := row.Fn(row.Input)
+			if row.Err != "" {
+				var nnv ErrNameNotValid
+				if !errors.As(err, &nnv) {
+					t.Errorf("unexpected error: %v", err)
+				} else {
+					compare[string](t, "Message", row.Err, nnv.Message)
+				}
+				return
+			}
+			compareError(t, "error", nil, err)
+			compare[string](t, "result", row.Expect, actual)
+		})
+	}
+}
+
+func TestHashGenerator_CanonicalizeNames(t *testing.T) {
+	g, err := NewHashGenerator(5, Options{Namespace: NamespaceDNS, CanonicalizeNames: true})
+	if err != nil {
+		t.Fatalf("NewHashGenerator: unexpected error: %v", err)
+	}
+
+	// RFC 9562 Appendix A.4.
+	for _, name := range []string{"www.example.com", "WWW.Example.COM", "www.example.com."} {
+		uuid, err := g.NewHashUUID([]byte(name))
+		compareError(t, name, nil, err)
+		compare[string](t, name, "2ed6657d-e927-568b-95e1-2665a8aea6a2", uuid.String())
+	}
+
+	_, err = g.NewHashUUID([]byte("a..b"))
+	compareError(t, "a..b", ErrNameNotValid{Kind: NameDNS, Name: "a..b", Message: "name contains an empty label"}, err)
+
+	g, err = NewHashGenerator(5, Options{Namespace: uuidV4, CanonicalizeNames: true})
+	if err != nil {
+		t.Fatalf("NewHashGenerator: unexpected error: %v", err)
+	}
+	_, err = g.NewHashUUID([]byte("a..b"))
+	compareError(t, "custom namespace", nil, err)
+}
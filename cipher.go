@@ -0,0 +1,145 @@
+package youyouayedee
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+)
+
+// uuidCipherRounds is the number of Feistel rounds.  Luby and Rackoff showed
+// that 4 rounds suffice for a strong pseudorandom permutation; the extra
+// rounds provide a comfortable margin, as in NIST FF1 and FF3-1.
+const uuidCipherRounds = 12
+
+// uuidCipherHalfBits is the width of each Feistel half.
+const uuidCipherHalfBits = 61
+
+// UUIDCipher is a reversible keyed permutation of UUIDs.  It maps internal
+// UUIDs, such as time-ordered V1, V6, or V7 UUIDs which reveal their creation
+// time, to opaque UUIDs which look like V4 or V8 UUIDs and are unlinkable
+// without the key, and back again.
+//
+// The 122 bits of a UUID which are not version or variant bits are encrypted
+// with a 12-round balanced Feistel network over two 61-bit halves, using AES
+// as the round function.  This is a format-preserving encryption scheme in
+// the same family as NIST FF1, but it is not FF1 itself, and its output is
+// specific to this library.
+//
+// Encryption is deterministic: the same internal UUID always maps to the same
+// public UUID under the same key.
+//
+// A UUIDCipher is safe for concurrent use.
+//
+type UUIDCipher struct {
+	block    cipher.Block
+	internal Version
+	public   Version
+}
+
+// NewUUIDCipher constructs a UUIDCipher.  The key must be 16, 24, or 32 bytes
+// long, selecting AES-128, AES-192, or AES-256.  The internal version is the
+// version of the UUIDs to be encrypted, and the public version, which must be
+// 4 or 8, is the version of the resulting UUIDs.
+//
+// The internal version is restored on decryption, so all UUIDs encrypted by
+// one UUIDCipher must share a version.  Use one UUIDCipher per internal
+// version, with distinct keys.
+//
+func NewUUIDCipher(key []byte, internal Version, public Version) (*UUIDCipher, error) {
+	if !internal.IsValid() {
+		return nil, ErrVersionMismatch{Requested: internal, Expected: []Version{1, 2, 3, 4, 5, 6, 7, 8}}
+	}
+	if public != 4 && public != 8 {
+		return nil, ErrVersionMismatch{Requested: public, Expected: []Version{4, 8}}
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrOperationFailed{Operation: InitializeCipherOp, Err: err}
+	}
+	return &UUIDCipher{block: block, internal: internal, public: public}, nil
+}
+
+// Encrypt maps an internal UUID to its public form.  The UUID must be an RFC
+// 4122 variant UUID of the internal version.
+//
+func (c *UUIDCipher) Encrypt(uuid UUID) (UUID, error) {
+	if err := c.check(uuid, c.internal); err != nil {
+		return Nil, err
+	}
+
+	left, right := splitUUIDCipherHalves(uuid)
+	for round := 0; round < uuidCipherRounds; round++ {
+		left, right = right, left^c.round(round, right)
+	}
+	return joinUUIDCipherHalves(left, right, c.public), nil
+}
+
+// Decrypt maps a public UUID produced by Encrypt back to the internal UUID.
+// The UUID must be an RFC 4122 variant UUID of the public version.
+//
+// Decrypt cannot detect tampering: every public UUID decrypts to some
+// internal UUID.  Callers should treat the result as untrusted input, and
+// look it up rather than assume that it exists.
+//
+func (c *UUIDCipher) Decrypt(uuid UUID) (UUID, error) {
+	if err := c.check(uuid, c.public); err != nil {
+		return Nil, err
+	}
+
+	left, right := splitUUIDCipherHalves(uuid)
+	for round := uuidCipherRounds - 1; round >= 0; round-- {
+		left, right = right^c.round(round, left), left
+	}
+	return joinUUIDCipherHalves(left, right, c.internal), nil
+}
+
+func (c *UUIDCipher) check(uuid UUID, version Version) error {
+	if uuid.Variant() != VariantRFC4122 || uuid.Version() != version {
+		return ErrCipherInputNotValid{UUID: uuid, Version: version}
+	}
+	return nil
+}
+
+// round computes the Feistel round function for the given round number.  The
+// versions are included in the input, so that ciphers with the same key but
+// different versions are unrelated.
+func (c *UUIDCipher) round(round int, half uint64) uint64 {
+	var block [aes.BlockSize]byte
+	block[0] = byte(round)
+	block[1] = byte(c.internal)
+	block[2] = byte(c.public)
+	binary.BigEndian.PutUint64(block[8:16], half)
+	c.block.Encrypt(block[:], block[:])
+	return binary.BigEndian.Uint64(block[0:8]) & widthMask(uuidCipherHalfBits)
+}
+
+// splitUUIDCipherHalves packs the 122 non-fixed bits of a UUID into a 128-bit
+// value, skipping the version and variant bits, and returns its top and bottom
+// 61 bits.
+func splitUUIDCipherHalves(uuid UUID) (uint64, uint64) {
+	hi, lo := uuid.uint128()
+	var ph, pl uint64
+	ph, pl = putBits128(ph, pl, 6, 48, getBits128(hi, lo, 0, 48))
+	ph, pl = putBits128(ph, pl, 54, 12, getBits128(hi, lo, 52, 12))
+	ph, pl = putBits128(ph, pl, 66, 62, getBits128(hi, lo, 66, 62))
+	return getBits128(ph, pl, 6, uuidCipherHalfBits), getBits128(ph, pl, 67, uuidCipherHalfBits)
+}
+
+// joinUUIDCipherHalves is the inverse of splitUUIDCipherHalves.
+func joinUUIDCipherHalves(left uint64, right uint64, version Version) UUID {
+	var ph, pl uint64
+	ph, pl = putBits128(ph, pl, 6, uuidCipherHalfBits, left)
+	ph, pl = putBits128(ph, pl, 67, uuidCipherHalfBits, right)
+
+	var hi, lo uint64
+	hi, lo = putBits128(hi, lo, 0, 48, getBits128(ph, pl, 6, 48))
+	hi, lo = putBits128(hi, lo, 52, 12, getBits128(ph, pl, 54, 12))
+	hi, lo = putBits128(hi, lo, 66, 62, getBits128(ph, pl, 66, 62))
+
+	var uuid UUID
+	uuid.putUint128(hi, lo)
+	uuid[6] = (uuid[6] & 0x0f) | byte(version<<4)
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return uuid
+}
@@ -0,0 +1,77 @@
+package youyouayedee
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestUUIDCipher(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 16)
+
+	c, err := NewUUIDCipher(key, 7, 4)
+	if err != nil {
+		t.Fatalf("NewUUIDCipher: unexpected error: %v", err)
+	}
+
+	public, err := c.Encrypt(uuidV7A)
+	compareError(t, "Encrypt", nil, err)
+	compare[Version](t, "Encrypt/Version", 4, public.Version())
+	compare[Variant](t, "Encrypt/Variant", VariantRFC4122, public.Variant())
+
+	internal, err := c.Decrypt(public)
+	compareError(t, "Decrypt", nil, err)
+	compare[UUID](t, "Decrypt", uuidV7A, internal)
+
+	// UUIDs which differ only in a few bits must not be linkable.
+	other, _ := c.Encrypt(uuidV7B)
+	if public == other || bytes.Equal(public[0:6], other[0:6]) {
+		t.Errorf("Encrypt: %v and %v are too similar", public, other)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	seen := make(map[UUID]bool)
+	for i := 0; i < 1000; i++ {
+		var uuid UUID
+		_, _ = rng.Read(uuid[:])
+		uuid[6] = (uuid[6] & 0x0f) | 0x70
+		uuid[8] = (uuid[8] & 0x3f) | 0x80
+		encrypted, err := c.Encrypt(uuid)
+		compareError(t, "Encrypt", nil, err)
+		if seen[encrypted] {
+			t.Errorf("Encrypt: duplicate output %v", encrypted)
+		}
+		seen[encrypted] = true
+		decrypted, err := c.Decrypt(encrypted)
+		compareError(t, "Decrypt", nil, err)
+		compare[UUID](t, "Decrypt", uuid, decrypted)
+	}
+
+	c8, err := NewUUIDCipher(key, 7, 8)
+	if err != nil {
+		t.Fatalf("NewUUIDCipher: unexpected error: %v", err)
+	}
+	public8, _ := c8.Encrypt(uuidV7A)
+	compare[Version](t, "Encrypt/V8", 8, public8.Version())
+	if bytes.Equal(public8[0:6], public[0:6]) {
+		t.Errorf("Encrypt: V4 and V8 ciphers produced related output %v and %v", public, public8)
+	}
+
+	_, err = c.Encrypt(uuidV4)
+	compareError(t, "Encrypt/wrong-version", ErrCipherInputNotValid{UUID: uuidV4, Version: 7}, err)
+
+	_, err = c.Decrypt(uuidV7A)
+	compareError(t, "Decrypt/wrong-version", ErrCipherInputNotValid{UUID: uuidV7A, Version: 4}, err)
+
+	_, err = NewUUIDCipher(key, 7, 5)
+	compareError(t, "NewUUIDCipher/public", ErrVersionMismatch{Requested: 5, Expected: []Version{4, 8}}, err)
+
+	_, err = NewUUIDCipher(key[:5], 7, 4)
+	var opErr ErrOperationFailed
+	if !errors.As(err, &opErr) {
+		t.Errorf("NewUUIDCipher/key: unexpected error: %v", err)
+	} else {
+		compare[Operation](t, "NewUUIDCipher/key", InitializeCipherOp, opErr.Operation)
+	}
+}
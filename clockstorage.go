@@ -220,3 +220,75 @@ var (
 	_ ClockStorage = (*ClockStorageFile)(nil)
 	_ io.Closer    = (*ClockStorageFile)(nil)
 )
+
+// clockState holds the state which the time-based generators share: the
+// Node, the time source, and the last time and clock sequence values, which
+// are loaded from and saved to a ClockStorage under a mutex.
+type clockState struct {
+	node    Node
+	now     func() time.Time
+	cs      ClockStorage
+	mu      sync.Mutex
+	started bool
+	last    time.Time
+	clock   uint32
+}
+
+// init fills in s from o, generating a Node if o.Node is zero, and loads any
+// saved state for that Node.  If saved state was found, then s.started is set.
+func (s *clockState) init(o Options) error {
+	var err error
+
+	s.node = o.Node
+	if s.node.IsZero() {
+		s.node, err = GenerateNode(o)
+		if err != nil {
+			return ErrOperationFailed{Operation: GenerateNodeOp, Err: err}
+		}
+	}
+
+	s.now = o.TimeSource
+	if s.now == nil {
+		s.now = time.Now
+	}
+
+	s.cs = o.ClockStorage
+	if s.cs == nil {
+		s.cs = ClockStorageUnavailable{}
+	}
+
+	s.last, s.clock, err = s.cs.Load(s.node)
+	if err != nil {
+		if !isErrClockNotFound(err) {
+			return ErrOperationFailed{Operation: ClockStorageLoadOp, Err: err}
+		}
+		return nil
+	}
+
+	s.started = true
+	return nil
+}
+
+// advance calls step with the mutex held, passing the current time, and then
+// saves the last time and clock sequence values which step returns.  The
+// previous values are available to step as s.last and s.clock, which are
+// only replaced once the new values have been saved.
+func (s *clockState) advance(step func(now time.Time) (time.Time, uint32, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, clock, err := step(s.now())
+	if err != nil {
+		return err
+	}
+
+	err = s.cs.Store(s.node, last, clock)
+	if err != nil {
+		return ErrOperationFailed{Operation: ClockStorageStoreOp, Err: err}
+	}
+
+	s.started = true
+	s.last = last
+	s.clock = clock
+	return nil
+}
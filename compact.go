@@ -0,0 +1,406 @@
+package youyouayedee
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+)
+
+// CompactLength is the length of a UUID in each of the compact text forms:
+// base58, base62, and unpadded base64url.
+const CompactLength = 22
+
+const (
+	base58Encode = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Encode = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	base64Encode = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+)
+
+var (
+	base58Decode = makeDecodeTable(base58Encode)
+	base62Decode = makeDecodeTable(base62Encode)
+	base64Decode = makeDecodeTable(base64Encode)
+)
+
+var base64Encoding = base64.NewEncoding(base64Encode).WithPadding(base64.NoPadding).Strict()
+
+// Base58 formats the UUID's 128 bits as 22 digits of base58, using the
+// Bitcoin alphabet.
+//
+// The output is left-padded with "1", the zero digit, so it always has the
+// same length.  The Bitcoin alphabet is in ASCII order, so sorting the
+// outputs as strings gives the same order as sorting the UUIDs as bytes.
+//
+func (uuid UUID) Base58() string {
+	var tmp [CompactLength]byte
+	return string(uuid.AppendBase58(tmp[:0]))
+}
+
+// AppendBase58 appends the UUID's base58 form to the given []byte.
+func (uuid UUID) AppendBase58(out []byte) []byte {
+	return appendBaseN(out, uuid, base58Encode)
+}
+
+// Base62 formats the UUID's 128 bits as 22 digits of base62, using the
+// alphabet "0-9A-Za-z".
+//
+// The output is left-padded with "0", so it always has the same length.  The
+// alphabet is in ASCII order, so sorting the outputs as strings gives the
+// same order as sorting the UUIDs as bytes.
+//
+func (uuid UUID) Base62() string {
+	var tmp [CompactLength]byte
+	return string(uuid.AppendBase62(tmp[:0]))
+}
+
+// AppendBase62 appends the UUID's base62 form to the given []byte.
+func (uuid UUID) AppendBase62(out []byte) []byte {
+	return appendBaseN(out, uuid, base62Encode)
+}
+
+// Base64 formats the UUID's 16 bytes as 22 characters of unpadded base64url,
+// as specified by RFC 4648.
+//
+// Unlike base58 and base62, the base64url alphabet is not in ASCII order, so
+// this form does not preserve the sort order of the UUIDs.
+//
+func (uuid UUID) Base64() string {
+	var tmp [CompactLength]byte
+	return string(uuid.AppendBase64(tmp[:0]))
+}
+
+// AppendBase64 appends the UUID's base64url form to the given []byte.
+func (uuid UUID) AppendBase64(out []byte) []byte {
+	var tmp [CompactLength]byte
+	base64Encoding.Encode(tmp[:], uuid[:])
+	return append(out, tmp[:]...)
+}
+
+// ParseBase58 parses a UUID in the 22-character base58 form produced by
+// UUID.Base58.  The result is not checked for validity.
+//
+func ParseBase58(str string) (UUID, error) {
+	var tmp [64]byte
+	input := append(tmp[:0], str...)
+	return parseBaseN(input, &base58Decode, 58, "base58")
+}
+
+// ParseBase62 parses a UUID in the 22-character base62 form produced by
+// UUID.Base62.  The result is not checked for validity.
+//
+func ParseBase62(str string) (UUID, error) {
+	var tmp [64]byte
+	input := append(tmp[:0], str...)
+	return parseBaseN(input, &base62Decode, 62, "base62")
+}
+
+// ParseBase64 parses a UUID in the 22-character unpadded base64url form
+// produced by UUID.Base64.  The result is not checked for validity.
+//
+func ParseBase64(str string) (UUID, error) {
+	var tmp [64]byte
+	input := append(tmp[:0], str...)
+	return parseBase64(input)
+}
+
+// Base58UUID is a wrapper type for UUID whose text and JSON representations
+// use the compact base58 form.  See UUID.Base58.
+//
+// SQL databases will store it as a 22-character string.
+//
+type Base58UUID struct {
+	UUID UUID
+}
+
+// Base62UUID is a wrapper type for UUID whose text and JSON representations
+// use the compact base62 form.  See UUID.Base62.
+//
+// SQL databases will store it as a 22-character string.
+//
+type Base62UUID struct {
+	UUID UUID
+}
+
+// Base64UUID is a wrapper type for UUID whose text and JSON representations
+// use the compact unpadded base64url form.  See UUID.Base64.
+//
+// SQL databases will store it as a 22-character string.
+//
+type Base64UUID struct {
+	UUID UUID
+}
+
+// String returns the UUID in base58 form.
+func (x Base58UUID) String() string {
+	return x.UUID.Base58()
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (x Base58UUID) MarshalText() ([]byte, error) {
+	return x.UUID.AppendBase58(make([]byte, 0, CompactLength)), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (x Base58UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (x *Base58UUID) UnmarshalText(text []byte) error {
+	var err error
+	x.UUID, err = parseBaseN(text, &base58Decode, 58, "base58")
+	return err
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (x *Base58UUID) UnmarshalJSON(data []byte) error {
+	return unmarshalCompactJSON(&x.UUID, data, ParseBase58)
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (x *Base58UUID) Scan(value interface{}) error {
+	return scanCompact(&x.UUID, value, ParseBase58)
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (x Base58UUID) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+// String returns the UUID in base62 form.
+func (x Base62UUID) String() string {
+	return x.UUID.Base62()
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (x Base62UUID) MarshalText() ([]byte, error) {
+	return x.UUID.AppendBase62(make([]byte, 0, CompactLength)), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (x Base62UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (x *Base62UUID) UnmarshalText(text []byte) error {
+	var err error
+	x.UUID, err = parseBaseN(text, &base62Decode, 62, "base62")
+	return err
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (x *Base62UUID) UnmarshalJSON(data []byte) error {
+	return unmarshalCompactJSON(&x.UUID, data, ParseBase62)
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (x *Base62UUID) Scan(value interface{}) error {
+	return scanCompact(&x.UUID, value, ParseBase62)
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (x Base62UUID) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+// String returns the UUID in base64url form.
+func (x Base64UUID) String() string {
+	return x.UUID.Base64()
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (x Base64UUID) MarshalText() ([]byte, error) {
+	return x.UUID.AppendBase64(make([]byte, 0, CompactLength)), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (x Base64UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (x *Base64UUID) UnmarshalText(text []byte) error {
+	var err error
+	x.UUID, err = parseBase64(text)
+	return err
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (x *Base64UUID) UnmarshalJSON(data []byte) error {
+	return unmarshalCompactJSON(&x.UUID, data, ParseBase64)
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (x *Base64UUID) Scan(value interface{}) error {
+	return scanCompact(&x.UUID, value, ParseBase64)
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (x Base64UUID) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+func unmarshalCompactJSON(uuid *UUID, data []byte, fn func(string) (UUID, error)) error {
+	*uuid = Nil
+
+	if len(data) == 4 && string(data) == "null" {
+		return nil
+	}
+
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		*uuid, err = fn(str)
+	}
+	return err
+}
+
+func scanCompact(uuid *UUID, value interface{}, fn func(string) (UUID, error)) error {
+	var err error
+	*uuid = Nil
+	switch x := value.(type) {
+	case nil:
+		err = nil
+
+	case string:
+		*uuid, err = fn(x)
+
+	case []byte:
+		*uuid, err = fn(string(x))
+
+	default:
+		err = fmt.Errorf("don't know how to interpret a value of type %T as a UUID", value)
+	}
+	return err
+}
+
+// appendBaseN appends the UUID's 128 bits as CompactLength digits in the
+// base given by the length of the alphabet, most significant digit first.
+func appendBaseN(out []byte, uuid UUID, alphabet string) []byte {
+	base := uint64(len(alphabet))
+	hi, lo := uuid.uint128()
+
+	var digits [CompactLength]byte
+	for di := CompactLength - 1; di >= 0; di-- {
+		var rem uint64
+		hi, rem = hi/base, hi%base
+		lo, rem = bits.Div64(rem, lo, base)
+		digits[di] = alphabet[rem]
+	}
+	return append(out, digits[:]...)
+}
+
+// parseBaseN is the inverse of appendBaseN.
+func parseBaseN(input []byte, table *[256]byte, base uint64, name string) (UUID, error) {
+	inputLen := uint(len(input))
+	if inputLen != CompactLength {
+		return Nil, ErrParseFailed{
+			Input:   input,
+			Problem: WrongInputLength,
+			Args:    mkargs(inputLen, name, uint(CompactLength)),
+		}
+	}
+
+	var hi, lo uint64
+	for ii := uint(0); ii < inputLen; ii++ {
+		ch := input[ii]
+		value := table[ch]
+		if value == 0xff {
+			return Nil, ErrParseFailed{
+				Input:      input,
+				Problem:    UnexpectedCharacter,
+				Args:       mkargs(ch, ii, name+" digit"),
+				Index:      ii,
+				ActualByte: ch,
+			}
+		}
+
+		overflow, newHi := bits.Mul64(hi, base)
+		carry, newLo := bits.Mul64(lo, base)
+		newHi, c0 := bits.Add64(newHi, carry, 0)
+		newLo, c1 := bits.Add64(newLo, uint64(value), 0)
+		newHi, c2 := bits.Add64(newHi, c1, 0)
+		if overflow != 0 || c0 != 0 || c2 != 0 {
+			return Nil, ErrParseFailed{
+				Input:   input,
+				Problem: ValueOverflow,
+				Args:    mkargs(name),
+			}
+		}
+		hi, lo = newHi, newLo
+	}
+
+	var uuid UUID
+	uuid.putUint128(hi, lo)
+	return uuid, nil
+}
+
+func parseBase64(input []byte) (UUID, error) {
+	inputLen := uint(len(input))
+	if inputLen != CompactLength {
+		return Nil, ErrParseFailed{
+			Input:   input,
+			Problem: WrongInputLength,
+			Args:    mkargs(inputLen, "base64url", uint(CompactLength)),
+		}
+	}
+
+	for ii := uint(0); ii < inputLen; ii++ {
+		ch := input[ii]
+		if base64Decode[ch] == 0xff {
+			return Nil, ErrParseFailed{
+				Input:      input,
+				Problem:    UnexpectedCharacter,
+				Args:       mkargs(ch, ii, "base64url digit"),
+				Index:      ii,
+				ActualByte: ch,
+			}
+		}
+	}
+
+	// All characters are valid, so the decoder can only reject the input
+	// because the last character has non-zero padding bits.
+	var uuid UUID
+	_, err := base64Encoding.Decode(uuid[:], input)
+	if err != nil {
+		index := inputLen - 1
+		ch := input[index]
+		return Nil, ErrParseFailed{
+			Input:      input,
+			Problem:    UnexpectedCharacter,
+			Args:       mkargs(ch, index, "base64url digit with zero low bits"),
+			Index:      index,
+			ActualByte: ch,
+		}
+	}
+	return uuid, nil
+}
+
+var (
+	_ encoding.TextMarshaler   = Base58UUID{}
+	_ json.Marshaler           = Base58UUID{}
+	_ driver.Valuer            = Base58UUID{}
+	_ fmt.Stringer             = Base58UUID{}
+	_ encoding.TextMarshaler   = Base62UUID{}
+	_ json.Marshaler           = Base62UUID{}
+	_ driver.Valuer            = Base62UUID{}
+	_ fmt.Stringer             = Base62UUID{}
+	_ encoding.TextMarshaler   = Base64UUID{}
+	_ json.Marshaler           = Base64UUID{}
+	_ driver.Valuer            = Base64UUID{}
+	_ fmt.Stringer             = Base64UUID{}
+	_ encoding.TextUnmarshaler = (*Base58UUID)(nil)
+	_ json.Unmarshaler         = (*Base58UUID)(nil)
+	_ sql.Scanner              = (*Base58UUID)(nil)
+	_ encoding.TextUnmarshaler = (*Base62UUID)(nil)
+	_ json.Unmarshaler         = (*Base62UUID)(nil)
+	_ sql.Scanner              = (*Base62UUID)(nil)
+	_ encoding.TextUnmarshaler = (*Base64UUID)(nil)
+	_ json.Unmarshaler         = (*Base64UUID)(nil)
+	_ sql.Scanner              = (*Base64UUID)(nil)
+)
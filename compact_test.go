@@ -0,0 +1,137 @@
+package youyouayedee
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	type testRow struct {
+		UUID   UUID
+		Base58 string
+		Base62 string
+		Base64 string
+	}
+
+	testData := [...]testRow{
+		{UUID: Nil, Base58: "1111111111111111111111", Base62: "0000000000000000000000", Base64: "AAAAAAAAAAAAAAAAAAAAAA"},
+		{UUID: Max, Base58: "YcVfxkQb6JRzqk5kF2tNLv", Base62: "7n42DGM5Tflk9n8mt7Fhc7", Base64: "_____________________w"},
+		{UUID: uuidV1, Base58: "TAuBtebJfpCRyouLdjbfV7", Base62: "6RuoWfjYWaV7WHvm655K6w", Base64: "0-92AGqVEeySNCNYhAxA5g"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.UUID.String(), func(t *testing.T) {
+			compare[string](t, "Base58", row.Base58, row.UUID.Base58())
+			compare[string](t, "Base62", row.Base62, row.UUID.Base62())
+			compare[string](t, "Base64", row.Base64, row.UUID.Base64())
+
+			parsed, err := ParseBase58(row.Base58)
+			compareError(t, "ParseBase58", nil, err)
+			compare[UUID](t, "ParseBase58", row.UUID, parsed)
+
+			parsed, err = ParseBase62(row.Base62)
+			compareError(t, "ParseBase62", nil, err)
+			compare[UUID](t, "ParseBase62", row.UUID, parsed)
+
+			parsed, err = ParseBase64(row.Base64)
+			compareError(t, "ParseBase64", nil, err)
+			compare[UUID](t, "ParseBase64", row.UUID, parsed)
+		})
+	}
+
+	_, err := ParseBase58("YcVfxkQb6JRzqk5kF2tNLw")
+	compareError(t, "ParseBase58/overflow", ErrParseFailed{
+		Input:   []byte("YcVfxkQb6JRzqk5kF2tNLw"),
+		Problem: ValueOverflow,
+		Args:    mkargs("base58"),
+	}, err)
+
+	_, err = ParseBase62("7n42DGM5Tflk9n8mt7Fhc8")
+	compareError(t, "ParseBase62/overflow", ErrParseFailed{
+		Input:   []byte("7n42DGM5Tflk9n8mt7Fhc8"),
+		Problem: ValueOverflow,
+		Args:    mkargs("base62"),
+	}, err)
+
+	_, err = ParseBase58("TAuBtebJfpCRyouLdjbf0V")
+	compareError(t, "ParseBase58/badchar", ErrParseFailed{
+		Input:      []byte("TAuBtebJfpCRyouLdjbf0V"),
+		Problem:    UnexpectedCharacter,
+		Args:       mkargs(byte('0'), uint(20), "base58 digit"),
+		Index:      20,
+		ActualByte: '0',
+	}, err)
+
+	_, err = ParseBase64("0-92AGqVEeySNCNYhAxA5h")
+	compareError(t, "ParseBase64/trailing", ErrParseFailed{
+		Input:      []byte("0-92AGqVEeySNCNYhAxA5h"),
+		Problem:    UnexpectedCharacter,
+		Args:       mkargs(byte('h'), uint(21), "base64url digit with zero low bits"),
+		Index:      21,
+		ActualByte: 'h',
+	}, err)
+
+	_, err = ParseBase62("6RuoWfjYWaV7WHvm655K6")
+	compareError(t, "ParseBase62/short", ErrParseFailed{
+		Input:   []byte("6RuoWfjYWaV7WHvm655K6"),
+		Problem: WrongInputLength,
+		Args:    mkargs(uint(21), "base62", uint(22)),
+	}, err)
+	compare[string](t, "ParseBase62/short/Error", `failed to parse "6RuoWfjYWaV7WHvm655K6" as UUID: unexpected input length 21 for base62; should be 22`, err.Error())
+
+	_, err = ParseBase64("0-92AGqVEeySNCNYhAxA5")
+	compareError(t, "ParseBase64/short", ErrParseFailed{
+		Input:   []byte("0-92AGqVEeySNCNYhAxA5"),
+		Problem: WrongInputLength,
+		Args:    mkargs(uint(21), "base64url", uint(22)),
+	}, err)
+}
+
+func TestCompact_SortOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	list := make([]UUID, 256)
+	for index := range list {
+		_, _ = rng.Read(list[index][:])
+	}
+	sort.Slice(list, func(i, j int) bool { return bytes.Compare(list[i][:], list[j][:]) < 0 })
+
+	for index := 1; index < len(list); index++ {
+		a, b := list[index-1], list[index]
+		if a.Base58() >= b.Base58() {
+			t.Errorf("Base58: %q >= %q", a.Base58(), b.Base58())
+		}
+		if a.Base62() >= b.Base62() {
+			t.Errorf("Base62: %q >= %q", a.Base62(), b.Base62())
+		}
+	}
+}
+
+func TestCompact_JSON(t *testing.T) {
+	type record struct {
+		A Base58UUID `json:"a"`
+		B Base62UUID `json:"b"`
+		C Base64UUID `json:"c"`
+	}
+
+	rec := record{A: Base58UUID{uuidV1}, B: Base62UUID{uuidV1}, C: Base64UUID{uuidV1}}
+	data, err := json.Marshal(rec)
+	compareError(t, "Marshal", nil, err)
+	compare[string](t, "Marshal", `{"a":"TAuBtebJfpCRyouLdjbfV7","b":"6RuoWfjYWaV7WHvm655K6w","c":"0-92AGqVEeySNCNYhAxA5g"}`, string(data))
+
+	var rec2 record
+	err = json.Unmarshal(data, &rec2)
+	compareError(t, "Unmarshal", nil, err)
+	compare[record](t, "Unmarshal", rec, rec2)
+
+	err = json.Unmarshal([]byte(`{"a":null,"b":null,"c":null}`), &rec2)
+	compareError(t, "Unmarshal/null", nil, err)
+	compare[record](t, "Unmarshal/null", record{}, rec2)
+
+	var scanned Base62UUID
+	err = scanned.Scan([]byte("6RuoWfjYWaV7WHvm655K6w"))
+	compareError(t, "Scan", nil, err)
+	compare[UUID](t, "Scan", uuidV1, scanned.UUID)
+}
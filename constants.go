@@ -14,9 +14,30 @@ const (
 	daysFromGregorianEpochToUnixEpoch    = 141427
 	secondsFromGregorianEpochToUnixEpoch = daysFromGregorianEpochToUnixEpoch * 86400
 
+	daysFromNTPEpochToUnixEpoch    = 25567
+	secondsFromNTPEpochToUnixEpoch = daysFromNTPEpochToUnixEpoch * 86400
+
+	// taiMinusUTCAtZeroLeapSeconds is the TAI-UTC offset, in seconds,
+	// which corresponds to a LeapSecondCalculator result of zero.  The
+	// 1972-01-01 realignment of UTC to TAI-UTC = 10 seconds counts as the
+	// first leap second.
+	taiMinusUTCAtZeroLeapSeconds = 9
+
+	// gpsEpochUnixSeconds is the Unix time of the GPS epoch,
+	// 1980-01-06T00:00:00Z, when TAI-UTC was 19 seconds.
+	gpsEpochUnixSeconds = 315964800
+	taiMinusGPS         = 19
+
+	// ncsEpochUnixSeconds is the Unix time of the Apollo NCS UUID epoch,
+	// 1980-01-01T00:00:00Z.
+	ncsEpochUnixSeconds = 315532800
+
 	nanosPerSecond  = 1000000000
 	nanosPerMilli   = 1000000
 	nanosPerTick    = 100
 	millisPerSecond = nanosPerSecond / nanosPerMilli
 	ticksPerSecond  = nanosPerSecond / nanosPerTick
+
+	nanosPerNCSTick   = 4000
+	ncsTicksPerSecond = nanosPerSecond / nanosPerNCSTick
 )
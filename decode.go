@@ -7,14 +7,51 @@ import (
 
 // Decode breaks down this UUID into its component fields.
 //
+// RFC 4122 variant UUIDs are decoded according to their version.  NCS variant
+// UUIDs are decoded as legacy Apollo Network Computing System UUIDs, which
+// carry a timestamp.  UUIDs of other variants are returned as opaque Data.
+// The nil UUID and the max UUID are never decoded.
+//
+// Only RFC 4122 variant UUIDs are marked as Valid.  For the other variants,
+// check Variant and then HasTicks or HasData.
+//
+// V8 UUIDs are returned as opaque Data, unless one or more V8Registry
+// arguments are given.  In that case, a V8 UUID which matches a layout in the
+// first registry that has one is also decoded according to that layout, as
+// V8Layout.Decode does.  No registry is consulted unless it is passed in.
+//
 // Only V1 and V6 UUIDs make use of the LeapSecondCalculator argument.  If it
 // is required but nil, then a LeapSecondCalculatorDummy will be used instead.
 //
-func (uuid UUID) Decode(lsc LeapSecondCalculator) Decoded {
+func (uuid UUID) Decode(lsc LeapSecondCalculator, registries ...*V8Registry) Decoded {
 	var result Decoded
 	var ticks uint64
 
-	if !uuid.IsValid() {
+	original := uuid
+
+	if uuid.IsZero() || uuid.IsMax() {
+		return result
+	}
+
+	result.Variant = uuid.Variant()
+	switch result.Variant {
+	case VariantNCS:
+		ticks = getUint48(uuid[0:6])
+		result.HasTicks = true
+		result.HasData = true
+		result.Time = ncsTicksToGoTime(ticks)
+		result.Ticks = int64(ticks)
+		result.Data = make([]byte, 10)
+		copy(result.Data[0:10], uuid[6:16])
+		return result
+
+	case VariantRFC4122:
+		// pass
+
+	default:
+		result.HasData = true
+		result.Data = make([]byte, Size)
+		copy(result.Data, uuid[:])
 		return result
 	}
 
@@ -76,6 +113,17 @@ func (uuid UUID) Decode(lsc LeapSecondCalculator) Decoded {
 			copy(result.Data[0:10], uuid[6:16])
 		}
 
+	case 8:
+		result.HasData = true
+		result.Data = make([]byte, Size)
+		copy(result.Data, uuid[:])
+		for _, r := range registries {
+			if c := r.lookup(original); c != nil {
+				c.decode(&result, original)
+				break
+			}
+		}
+
 	default:
 		result.HasData = true
 		result.Data = make([]byte, Size)
@@ -86,10 +134,21 @@ func (uuid UUID) Decode(lsc LeapSecondCalculator) Decoded {
 
 // Decoded holds the results of decoding a UUID into its components.
 type Decoded struct {
-	// Valid is true iff the UUID was successfully decoded to any degree.
+	// Valid is true iff the UUID is an RFC 4122 variant UUID and was
+	// successfully decoded to any degree.
+	//
+	// NCS, Microsoft, and reserved variant UUIDs are never Valid, although
+	// their Variant and other fields are still filled in.
+	//
 	Valid bool
 
+	// Variant holds the detected UUID variant.
+	Variant Variant
+
 	// Version holds the detected UUID version.
+	//
+	// Only valid for RFC 4122 variant UUIDs.
+	//
 	Version Version
 
 	// HasTicks is true iff the Time and Ticks fields are valid.
@@ -118,6 +177,9 @@ type Decoded struct {
 	// For V7 UUIDs, this is milliseconds since the start of the Unix epoch
 	// in 1970, with leap seconds omitted.
 	//
+	// For NCS variant UUIDs, this is units of 4 microseconds since
+	// 1980-01-01T00:00:00Z.
+	//
 	Ticks int64
 
 	// Counter holds the raw counter value from a time-based UUID.
@@ -155,5 +217,27 @@ type Decoded struct {
 	// For V3, V4, V5, and V8 UUIDs, this field contains almost all bits
 	// from the UUID.
 	//
+	// For NCS variant UUIDs, this field contains the 2 reserved bytes, the
+	// address family byte, and the 7 host ID bytes.
+	//
+	// For Microsoft and reserved variant UUIDs, this field contains all 16
+	// bytes of the UUID.
+	//
 	Data []byte
+
+	// Layout holds the name of the V8Layout that matched the UUID.
+	//
+	// Only valid for V8 UUIDs decoded by V8Layout.Decode,
+	// V8Registry.Decode, or UUID.Decode with a V8Registry.
+	// The Time, Ticks, and Counter fields are then filled from the layout's
+	// timestamp and counter fields, if it has them.
+	//
+	Layout string
+
+	// Fields holds the raw values of the matched V8Layout's fields, keyed
+	// by field name.
+	//
+	// Only valid if Layout is set.
+	//
+	Fields map[string]uint64
 }
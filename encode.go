@@ -0,0 +1,215 @@
+package youyouayedee
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Encode builds a UUID from its component fields.  It is the inverse of
+// UUID.Decode: for every UUID u except Nil and Max,
+// u.Decode(lsc).Encode(lsc) returns u.
+//
+// The fields used depend on Variant and Version:
+//
+//   - NCS variant: Ticks and Data (10 bytes)
+//   - V1 and V6: Ticks, Counter, and Node
+//   - V2: Domain, ID, and Data (11 bytes)
+//   - V7: Ticks, and either Counter and Data (5 bytes) if HasCounter is
+//     true, or else Data (10 bytes)
+//   - V3, V4, V5, V8, and other versions: Data (16 bytes)
+//   - Microsoft and reserved variants: Data (16 bytes)
+//
+// Valid must be true for RFC 4122 variant UUIDs, but is ignored for the other
+// variants, which UUID.Decode never marks as Valid.
+//
+// The Layout and Fields of V8 UUIDs are ignored; use V8Layout.Encode to build
+// a V8 UUID from its layout fields.
+//
+// For time-based UUIDs, if HasTicks is false then the timestamp is computed
+// from Time instead of Ticks.  Only V1 and V6 UUIDs make use of the
+// LeapSecondCalculator argument; if it is required but nil, then a
+// LeapSecondCalculatorDummy will be used instead.
+//
+// Values which are out of range, Data of the wrong length, and Data with
+// non-zero bits where the version or variant bits belong are all rejected
+// with ErrEncodeFailed, rather than being silently truncated.
+//
+func (d Decoded) Encode(lsc LeapSecondCalculator) (UUID, error) {
+	var uuid UUID
+
+	if lsc == nil {
+		lsc = LeapSecondCalculatorDummy{}
+	}
+
+	switch d.Variant {
+	case VariantNCS:
+		ticks, err := d.ncsTicks()
+		if err != nil {
+			return Nil, err
+		}
+		if err := d.checkDataLen(10); err != nil {
+			return Nil, err
+		}
+		if (d.Data[2] & 0x80) != 0 {
+			return Nil, ErrEncodeFailed{Field: DataField, Message: "variant bit must be zero"}
+		}
+		putUint48(uuid[0:6], ticks)
+		copy(uuid[6:16], d.Data)
+		return uuid, nil
+
+	case VariantRFC4122:
+		if !d.Valid {
+			return Nil, ErrEncodeFailed{Field: ValidField, Message: "decoded value is not valid"}
+		}
+
+	case VariantMicrosoft, VariantReserved:
+		if err := d.checkDataLen(Size); err != nil {
+			return Nil, err
+		}
+		copy(uuid[:], d.Data)
+		if actual := uuid.Variant(); actual != d.Variant {
+			return Nil, ErrEncodeFailed{Field: DataField, Message: fmt.Sprintf("variant bits encode %v, not %v", actual, d.Variant)}
+		}
+		return uuid, nil
+
+	default:
+		if !d.Valid {
+			return Nil, ErrEncodeFailed{Field: ValidField, Message: "decoded value is not valid"}
+		}
+		return Nil, ErrEncodeFailed{Field: VariantField, Message: fmt.Sprintf("unknown variant %v", d.Variant)}
+	}
+
+	if d.Version > 15 {
+		return Nil, ErrEncodeFailed{Field: VersionField, Message: fmt.Sprintf("version %d does not fit in 4 bits", uint(d.Version))}
+	}
+
+	switch d.Version {
+	case 1, 6:
+		ticks, err := d.gregorianTicks(lsc)
+		if err != nil {
+			return Nil, err
+		}
+		if d.Counter < 0 || d.Counter > clockMask {
+			return Nil, ErrEncodeFailed{Field: CounterField, Message: fmt.Sprintf("value %d is out of range [0, %d]", d.Counter, clockMask)}
+		}
+		if d.Version == 1 {
+			putV1Ticks(uuid[0:8], ticks)
+		} else {
+			putV6Ticks(uuid[0:8], ticks)
+		}
+		putClock14(uuid[8:10], uint32(d.Counter))
+		copy(uuid[10:16], d.Node[:])
+
+	case 2:
+		if err := d.checkDataLen(11); err != nil {
+			return Nil, err
+		}
+		if err := checkReservedBits(d.Data[2], d.Data[4]); err != nil {
+			return Nil, err
+		}
+		binary.BigEndian.PutUint32(uuid[0:4], d.ID)
+		copy(uuid[4:9], d.Data[0:5])
+		uuid[9] = byte(d.Domain)
+		copy(uuid[10:16], d.Data[5:11])
+
+	case 7:
+		ticks, err := d.unixTicks()
+		if err != nil {
+			return Nil, err
+		}
+		putUint48(uuid[0:6], ticks)
+		if d.HasCounter {
+			if d.Counter < 0 || uint64(d.Counter) > 0xffffffff {
+				return Nil, ErrEncodeFailed{Field: CounterField, Message: fmt.Sprintf("value %d is out of range [0, %d]", d.Counter, uint32(0xffffffff))}
+			}
+			if err := d.checkDataLen(5); err != nil {
+				return Nil, err
+			}
+			putClock32(uuid[6:11], uint32(d.Counter))
+			copy(uuid[11:16], d.Data)
+		} else {
+			if err := d.checkDataLen(10); err != nil {
+				return Nil, err
+			}
+			if err := checkReservedBits(d.Data[0], d.Data[2]); err != nil {
+				return Nil, err
+			}
+			copy(uuid[6:16], d.Data)
+		}
+
+	default:
+		if err := d.checkDataLen(Size); err != nil {
+			return Nil, err
+		}
+		if err := checkReservedBits(d.Data[6], d.Data[8]); err != nil {
+			return Nil, err
+		}
+		copy(uuid[:], d.Data)
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | byte(d.Version<<4)
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return uuid, nil
+}
+
+func (d Decoded) checkDataLen(expected int) error {
+	if len(d.Data) != expected {
+		return ErrEncodeFailed{Field: DataField, Message: fmt.Sprintf("length is %d bytes; should be %d bytes", len(d.Data), expected)}
+	}
+	return nil
+}
+
+func checkReservedBits(versionByte byte, variantByte byte) error {
+	if (versionByte & 0xf0) != 0 {
+		return ErrEncodeFailed{Field: DataField, Message: "version bits must be zero"}
+	}
+	if (variantByte & 0xc0) != 0 {
+		return ErrEncodeFailed{Field: DataField, Message: "variant bits must be zero"}
+	}
+	return nil
+}
+
+func (d Decoded) gregorianTicks(lsc LeapSecondCalculator) (uint64, error) {
+	if d.HasTicks {
+		if d.Ticks < 0 || d.Ticks > tickMask {
+			return 0, ErrEncodeFailed{Field: TicksField, Message: fmt.Sprintf("value %d is out of range [0, %d]", d.Ticks, int64(tickMask))}
+		}
+		return uint64(d.Ticks), nil
+	}
+
+	// Leave a margin at the upper end for leap seconds.
+	const minUnix = -secondsFromGregorianEpochToUnixEpoch
+	const maxUnix = (tickMask / ticksPerSecond) - secondsFromGregorianEpochToUnixEpoch - 86400
+	if s := d.Time.Unix(); s < minUnix || s > maxUnix {
+		return 0, ErrEncodeFailed{Field: TimeField, Message: fmt.Sprintf("value %v is out of range", d.Time)}
+	}
+	return goTimeToGregorianTicks(lsc, d.Time), nil
+}
+
+func (d Decoded) unixTicks() (uint64, error) {
+	ticks := d.Ticks
+	if !d.HasTicks {
+		ticks = d.Time.UnixMilli()
+	}
+	if ticks < -milliSignBit || ticks >= milliSignBit {
+		field := TicksField
+		if !d.HasTicks {
+			field = TimeField
+		}
+		return 0, ErrEncodeFailed{Field: field, Message: fmt.Sprintf("value %d ms is out of range [%d, %d)", ticks, int64(-milliSignBit), int64(milliSignBit))}
+	}
+	return uint64(ticks) & milliMask, nil
+}
+
+func (d Decoded) ncsTicks() (uint64, error) {
+	ticks := d.Ticks
+	if !d.HasTicks {
+		delta := d.Time.Sub(time.Unix(ncsEpochUnixSeconds, 0))
+		ticks = int64(delta / (nanosPerNCSTick * time.Nanosecond))
+	}
+	if ticks < 0 || ticks > milliMask {
+		return 0, ErrEncodeFailed{Field: TicksField, Message: fmt.Sprintf("value %d is out of range [0, %d]", ticks, int64(milliMask))}
+	}
+	return uint64(ticks), nil
+}
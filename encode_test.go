@@ -0,0 +1,113 @@
+package youyouayedee
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestEncode_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	lsc := LeapSecondCalculatorFixed{}
+	for index := 0; index < 4096; index++ {
+		var uuid UUID
+		_, _ = rng.Read(uuid[:])
+		if index%2 == 0 {
+			uuid[8] = (uuid[8] & 0x3f) | 0x80
+		}
+		if uuid.IsZero() || uuid.IsMax() {
+			continue
+		}
+
+		encoded, err := uuid.Decode(lsc).Encode(lsc)
+		if err != nil {
+			t.Errorf("%v: Encode: unexpected error: %v", uuid, err)
+			continue
+		}
+		if encoded != uuid {
+			t.Errorf("%v: Encode: round trip produced %v", uuid, encoded)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 12, 34, 56, 789000000, time.UTC)
+	node := Node{0x02, 0x00, 0x5e, 0x10, 0x20, 0x30}
+
+	for _, version := range []Version{1, 6} {
+		uuid, err := Decoded{
+			Valid:   true,
+			Variant: VariantRFC4122,
+			Version: version,
+			Time:    now,
+			Counter: 0x1234,
+			Node:    node,
+		}.Encode(nil)
+		compareError(t, "Encode", nil, err)
+		decoded := uuid.Decode(nil)
+		compare[Version](t, "Version", version, decoded.Version)
+		compare[time.Time](t, "Time", now, decoded.Time.UTC())
+		compare[int](t, "Counter", 0x1234, decoded.Counter)
+		compare[Node](t, "Node", node, decoded.Node)
+	}
+
+	uuid, err := Decoded{
+		Valid:      true,
+		Variant:    VariantRFC4122,
+		Version:    7,
+		Time:       now,
+		HasCounter: true,
+		Counter:    0xdeadbeef,
+		Data:       []byte{1, 2, 3, 4, 5},
+	}.Encode(nil)
+	compareError(t, "Encode", nil, err)
+	compare[string](t, "Encode", "017e15a2-c895-7dea-8dbe-ef0102030405", uuid.String())
+	decoded := uuid.Decode(nil)
+	compare[int64](t, "Ticks", now.UnixMilli(), decoded.Ticks)
+	compare[int](t, "Counter", 0xdeadbeef, decoded.Counter)
+
+	uuid, err = Decoded{
+		Valid:          true,
+		Variant:        VariantRFC4122,
+		Version:        2,
+		HasDomainAndID: true,
+		Domain:         Group,
+		ID:             1000,
+		Data:           make([]byte, 11),
+	}.Encode(nil)
+	compareError(t, "Encode", nil, err)
+	compare[string](t, "Encode", "000003e8-0000-2000-8001-000000000000", uuid.String())
+
+	type errorRow struct {
+		Name    string
+		Decoded Decoded
+		Field   DecodedField
+	}
+
+	errorData := [...]errorRow{
+		{Name: "invalid", Decoded: Decoded{}, Field: ValidField},
+		{Name: "no-variant", Decoded: Decoded{Valid: true}, Field: VariantField},
+		{Name: "version-16", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 16}, Field: VersionField},
+		{Name: "v1-ticks", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 1, HasTicks: true, Ticks: 1 << 60}, Field: TicksField},
+		{Name: "v1-time", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 1, Time: time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)}, Field: TimeField},
+		{Name: "v1-counter", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 1, HasTicks: true, Counter: 0x4000}, Field: CounterField},
+		{Name: "v7-ticks", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 7, HasTicks: true, Ticks: 1 << 47, Data: make([]byte, 10)}, Field: TicksField},
+		{Name: "v7-data", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 7, HasTicks: true, Data: make([]byte, 9)}, Field: DataField},
+		{Name: "v8-version-bits", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 8, Data: []byte{6: 0x10, 15: 0}}, Field: DataField},
+		{Name: "v8-variant-bits", Decoded: Decoded{Valid: true, Variant: VariantRFC4122, Version: 8, Data: []byte{8: 0x40, 15: 0}}, Field: DataField},
+		{Name: "microsoft-bits", Decoded: Decoded{Valid: true, Variant: VariantMicrosoft, Data: make([]byte, 16)}, Field: DataField},
+		{Name: "ncs-bits", Decoded: Decoded{Valid: true, Variant: VariantNCS, HasTicks: true, Data: []byte{2: 0x80, 9: 0}}, Field: DataField},
+	}
+
+	for _, row := range errorData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := row.Decoded.Encode(nil)
+			failed, ok := err.(ErrEncodeFailed)
+			if !ok {
+				t.Errorf("Encode: expected ErrEncodeFailed, got %#v", err)
+				return
+			}
+			compare[DecodedField](t, "Field", row.Field, failed.Field)
+		})
+	}
+}
@@ -56,6 +56,182 @@ var (
 	_ fmt.Stringer   = DCEDomain(0)
 )
 
+// HardwareAddressType indicates the format of the network hardware address
+// from which a node identifier was derived.
+type HardwareAddressType uint
+
+const (
+	_ HardwareAddressType = iota
+	EUI48
+	EUI64
+)
+
+var hardwareAddressTypeDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.HardwareAddressType(0)",
+		Name:   "hardware address type not specified",
+	},
+	{
+		GoName: "youyouayedee.EUI48",
+		Name:   "EUI-48",
+	},
+	{
+		GoName: "youyouayedee.EUI64",
+		Name:   "EUI-64",
+	},
+}
+
+func (enum HardwareAddressType) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(hardwareAddressTypeDataArray))
+	if p < q {
+		return hardwareAddressTypeDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.HardwareAddressType(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.HardwareAddressType enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum HardwareAddressType) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum HardwareAddressType) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = HardwareAddressType(0)
+	_ fmt.Stringer   = HardwareAddressType(0)
+)
+
+// Variant indicates the layout of a UUID, as determined by the high bits of
+// its 9th byte.
+type Variant byte
+
+const (
+	_ Variant = iota
+	VariantNCS
+	VariantRFC4122
+	VariantMicrosoft
+	VariantReserved
+)
+
+var variantDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.Variant(0)",
+		Name:   "variant not specified",
+	},
+	{
+		GoName: "youyouayedee.VariantNCS",
+		Name:   "NCS backward compatibility",
+	},
+	{
+		GoName: "youyouayedee.VariantRFC4122",
+		Name:   "RFC 4122",
+	},
+	{
+		GoName: "youyouayedee.VariantMicrosoft",
+		Name:   "Microsoft backward compatibility",
+	},
+	{
+		GoName: "youyouayedee.VariantReserved",
+		Name:   "reserved for future definition",
+	},
+}
+
+func (enum Variant) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(variantDataArray))
+	if p < q {
+		return variantDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.Variant(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.Variant enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum Variant) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum Variant) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = Variant(0)
+	_ fmt.Stringer   = Variant(0)
+)
+
+// Format enumerates the representations of a UUID.
+type Format uint
+
+const (
+	_ Format = iota
+	FormatCanonical
+	FormatHex
+	FormatBraced
+	FormatURN
+	FormatBinary
+	FormatULID
+)
+
+var formatDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.Format(0)",
+		Name:   "format not specified",
+	},
+	{
+		GoName: "youyouayedee.FormatCanonical",
+		Name:   "canonical",
+	},
+	{
+		GoName: "youyouayedee.FormatHex",
+		Name:   "hex",
+	},
+	{
+		GoName: "youyouayedee.FormatBraced",
+		Name:   "braced",
+	},
+	{
+		GoName: "youyouayedee.FormatURN",
+		Name:   "URN",
+	},
+	{
+		GoName: "youyouayedee.FormatBinary",
+		Name:   "binary",
+	},
+	{
+		GoName: "youyouayedee.FormatULID",
+		Name:   "ULID",
+	},
+}
+
+func (enum Format) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(formatDataArray))
+	if p < q {
+		return formatDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.Format(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.Format enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum Format) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum Format) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = Format(0)
+	_ fmt.Stringer   = Format(0)
+)
+
 // Method enumerates the Generator methods which do not need to be implemented.
 type Method uint
 
@@ -63,6 +239,10 @@ const (
 	_ Method = iota
 	MethodNewUUID
 	MethodNewHashUUID
+	MethodNewHashUUIDFromReader
+	MethodNewHashUUIDFromParts
+	MethodNewHashUUIDInNamespace
+	MethodNewHashUUIDFromPath
 )
 
 var methodDataArray = [...]EnumData{
@@ -78,6 +258,22 @@ var methodDataArray = [...]EnumData{
 		GoName: "youyouayedee.MethodNewHashUUID",
 		Name:   "NewHashUUID",
 	},
+	{
+		GoName: "youyouayedee.MethodNewHashUUIDFromReader",
+		Name:   "NewHashUUIDFromReader",
+	},
+	{
+		GoName: "youyouayedee.MethodNewHashUUIDFromParts",
+		Name:   "NewHashUUIDFromParts",
+	},
+	{
+		GoName: "youyouayedee.MethodNewHashUUIDInNamespace",
+		Name:   "NewHashUUIDInNamespace",
+	},
+	{
+		GoName: "youyouayedee.MethodNewHashUUIDFromPath",
+		Name:   "NewHashUUIDFromPath",
+	},
 }
 
 func (enum Method) Data() EnumData {
@@ -116,6 +312,12 @@ const (
 	InitializeBlakeHashOp
 	ReadRandomOp
 	NetInterfacesOp
+	MatchInterfaceNameOp
+	ReadHashInputOp
+	InitializeCipherOp
+	OpenLeapSecondFileOp
+	ReadLeapSecondDataOp
+	FindSystemLeapSecondsOp
 )
 
 var operationDataArray = [...]EnumData{
@@ -147,6 +349,30 @@ var operationDataArray = [...]EnumData{
 		GoName: "youyouayedee.NetInterfacesOp",
 		Name:   "failed to enumerate network interfaces using net.Interfaces()",
 	},
+	{
+		GoName: "youyouayedee.MatchInterfaceNameOp",
+		Name:   "failed to match network interface name against pattern",
+	},
+	{
+		GoName: "youyouayedee.ReadHashInputOp",
+		Name:   "failed to read input data for hashing",
+	},
+	{
+		GoName: "youyouayedee.InitializeCipherOp",
+		Name:   "failed to initialize block cipher",
+	},
+	{
+		GoName: "youyouayedee.OpenLeapSecondFileOp",
+		Name:   "failed to open leap second file",
+	},
+	{
+		GoName: "youyouayedee.ReadLeapSecondDataOp",
+		Name:   "failed to read leap second data",
+	},
+	{
+		GoName: "youyouayedee.FindSystemLeapSecondsOp",
+		Name:   "failed to find leap second data in the system timezone database",
+	},
 }
 
 func (enum Operation) Data() EnumData {
@@ -183,6 +409,15 @@ const (
 	WrongVariant
 	WrongTextLength
 	WrongBinaryLength
+	DisallowedFormat
+	DisallowedKeyword
+	DisallowedValue
+	WrongVersion
+	InvalidTypeIDPrefix
+	WrongTypeIDPrefix
+	ValueOverflow
+	WrongInputLength
+	WrongTypeIDSuffixLength
 )
 
 var parseProblemDataArray = [...]EnumData{
@@ -210,6 +445,51 @@ var parseProblemDataArray = [...]EnumData{
 		Name:   "wrong binary data input length",
 		Format: "unexpected input length %d for binary data; should be 0, 16, 32, 36, 38, or 41",
 	},
+	{
+		GoName: "youyouayedee.DisallowedFormat",
+		Name:   "disallowed input format",
+		Format: "input is in %s format, which is not allowed",
+	},
+	{
+		GoName: "youyouayedee.DisallowedKeyword",
+		Name:   "disallowed keyword",
+		Format: "keyword %q is not allowed",
+	},
+	{
+		GoName: "youyouayedee.DisallowedValue",
+		Name:   "disallowed UUID value",
+		Format: "the %s UUID is not allowed",
+	},
+	{
+		GoName: "youyouayedee.WrongVersion",
+		Name:   "wrong UUID version",
+		Format: "unexpected UUID version %d; should be one of %v",
+	},
+	{
+		GoName: "youyouayedee.InvalidTypeIDPrefix",
+		Name:   "invalid TypeID prefix",
+		Format: "invalid TypeID prefix %q; %s",
+	},
+	{
+		GoName: "youyouayedee.WrongTypeIDPrefix",
+		Name:   "wrong TypeID prefix",
+		Format: "unexpected TypeID prefix %q; should be %q",
+	},
+	{
+		GoName: "youyouayedee.ValueOverflow",
+		Name:   "value overflow",
+		Format: "%s value exceeds 128 bits",
+	},
+	{
+		GoName: "youyouayedee.WrongInputLength",
+		Name:   "wrong input length",
+		Format: "unexpected input length %d for %s; should be %d",
+	},
+	{
+		GoName: "youyouayedee.WrongTypeIDSuffixLength",
+		Name:   "wrong TypeID suffix length",
+		Format: "unexpected length %d for TypeID suffix %q after prefix %q; should be %d",
+	},
 }
 
 func (enum ParseProblem) Data() EnumData {
@@ -239,3 +519,438 @@ var (
 	_ fmt.GoStringer = ParseProblem(0)
 	_ fmt.Stringer   = ParseProblem(0)
 )
+
+// ValidationRule enumerates the rules which a Validator can check.
+type ValidationRule uint
+
+const (
+	_ ValidationRule = iota
+	RuleNotNilOrMax
+	RuleRFC4122Variant
+	RuleKnownVersion
+	RuleAllowedVersion
+	RuleTimeNotInFuture
+	RuleUnicastNode
+	RuleKnownDomain
+)
+
+var validationRuleDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.ValidationRule(0)",
+		Name:   "validation rule not specified",
+	},
+	{
+		GoName: "youyouayedee.RuleNotNilOrMax",
+		Name:   "not nil or max",
+		Format: "the %s UUID is not allowed",
+	},
+	{
+		GoName: "youyouayedee.RuleRFC4122Variant",
+		Name:   "RFC 4122 variant",
+		Format: "UUID has variant %v; only RFC 4122 variant UUIDs are allowed",
+	},
+	{
+		GoName: "youyouayedee.RuleKnownVersion",
+		Name:   "known version",
+		Format: "UUID has reserved version %d",
+	},
+	{
+		GoName: "youyouayedee.RuleAllowedVersion",
+		Name:   "allowed version",
+		Format: "UUID has version %d; should be one of %v",
+	},
+	{
+		GoName: "youyouayedee.RuleTimeNotInFuture",
+		Name:   "time not in future",
+		Format: "UUID timestamp %v is more than %v in the future",
+	},
+	{
+		GoName: "youyouayedee.RuleUnicastNode",
+		Name:   "unicast node",
+		Format: "UUID node %v is %s; should be a unicast address",
+	},
+	{
+		GoName: "youyouayedee.RuleKnownDomain",
+		Name:   "known DCE domain",
+		Format: "UUID has unknown DCE domain 0x%02x",
+	},
+}
+
+func (enum ValidationRule) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(validationRuleDataArray))
+	if p < q {
+		return validationRuleDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.ValidationRule(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.ValidationRule enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum ValidationRule) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum ValidationRule) String() string {
+	return enum.Data().Name
+}
+
+func (enum ValidationRule) FormatString() string {
+	return enum.Data().Format
+}
+
+var (
+	_ fmt.GoStringer = ValidationRule(0)
+	_ fmt.Stringer   = ValidationRule(0)
+)
+
+// DecodedField identifies a field of Decoded, for reporting which field could
+// not be encoded.
+type DecodedField uint
+
+const (
+	_ DecodedField = iota
+	ValidField
+	VariantField
+	VersionField
+	TicksField
+	TimeField
+	CounterField
+	DataField
+)
+
+var decodedFieldDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.DecodedField(0)",
+		Name:   "field not specified",
+	},
+	{
+		GoName: "youyouayedee.ValidField",
+		Name:   "Valid",
+	},
+	{
+		GoName: "youyouayedee.VariantField",
+		Name:   "Variant",
+	},
+	{
+		GoName: "youyouayedee.VersionField",
+		Name:   "Version",
+	},
+	{
+		GoName: "youyouayedee.TicksField",
+		Name:   "Ticks",
+	},
+	{
+		GoName: "youyouayedee.TimeField",
+		Name:   "Time",
+	},
+	{
+		GoName: "youyouayedee.CounterField",
+		Name:   "Counter",
+	},
+	{
+		GoName: "youyouayedee.DataField",
+		Name:   "Data",
+	},
+}
+
+func (enum DecodedField) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(decodedFieldDataArray))
+	if p < q {
+		return decodedFieldDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.DecodedField(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.DecodedField enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum DecodedField) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum DecodedField) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = DecodedField(0)
+	_ fmt.Stringer   = DecodedField(0)
+)
+
+// V8FieldKind indicates the meaning of a field within a V8Layout.
+type V8FieldKind uint
+
+const (
+	_ V8FieldKind = iota
+	V8FieldConstant
+	V8FieldTimestamp
+	V8FieldCounter
+	V8FieldRandom
+	V8FieldValue
+)
+
+var v8FieldKindDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.V8FieldKind(0)",
+		Name:   "field kind not specified",
+	},
+	{
+		GoName: "youyouayedee.V8FieldConstant",
+		Name:   "constant",
+	},
+	{
+		GoName: "youyouayedee.V8FieldTimestamp",
+		Name:   "timestamp",
+	},
+	{
+		GoName: "youyouayedee.V8FieldCounter",
+		Name:   "counter",
+	},
+	{
+		GoName: "youyouayedee.V8FieldRandom",
+		Name:   "random",
+	},
+	{
+		GoName: "youyouayedee.V8FieldValue",
+		Name:   "value",
+	},
+}
+
+func (enum V8FieldKind) IsValid() bool {
+	p := uint(enum)
+	q := uint(len(v8FieldKindDataArray))
+	return p > 0 && p < q
+}
+
+func (enum V8FieldKind) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(v8FieldKindDataArray))
+	if p < q {
+		return v8FieldKindDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.V8FieldKind(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.V8FieldKind enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum V8FieldKind) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum V8FieldKind) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = V8FieldKind(0)
+	_ fmt.Stringer   = V8FieldKind(0)
+)
+
+// HashAlgorithm identifies one of the hash algorithms for which RFC 9562
+// assigns a hash space ID, for use with name-based V8 UUIDs.
+type HashAlgorithm uint
+
+const (
+	_ HashAlgorithm = iota
+	HashSHA224
+	HashSHA256
+	HashSHA384
+	HashSHA512
+	HashSHA512_224
+	HashSHA512_256
+	HashSHA3_224
+	HashSHA3_256
+	HashSHA3_384
+	HashSHA3_512
+)
+
+var hashAlgorithmDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.HashAlgorithm(0)",
+		Name:   "hash algorithm not specified",
+	},
+	{
+		GoName: "youyouayedee.HashSHA224",
+		Name:   "SHA-224",
+	},
+	{
+		GoName: "youyouayedee.HashSHA256",
+		Name:   "SHA-256",
+	},
+	{
+		GoName: "youyouayedee.HashSHA384",
+		Name:   "SHA-384",
+	},
+	{
+		GoName: "youyouayedee.HashSHA512",
+		Name:   "SHA-512",
+	},
+	{
+		GoName: "youyouayedee.HashSHA512_224",
+		Name:   "SHA-512/224",
+	},
+	{
+		GoName: "youyouayedee.HashSHA512_256",
+		Name:   "SHA-512/256",
+	},
+	{
+		GoName: "youyouayedee.HashSHA3_224",
+		Name:   "SHA3-224",
+	},
+	{
+		GoName: "youyouayedee.HashSHA3_256",
+		Name:   "SHA3-256",
+	},
+	{
+		GoName: "youyouayedee.HashSHA3_384",
+		Name:   "SHA3-384",
+	},
+	{
+		GoName: "youyouayedee.HashSHA3_512",
+		Name:   "SHA3-512",
+	},
+}
+
+func (enum HashAlgorithm) IsValid() bool {
+	p := uint(enum)
+	q := uint(len(hashAlgorithmDataArray))
+	return p > 0 && p < q
+}
+
+func (enum HashAlgorithm) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(hashAlgorithmDataArray))
+	if p < q {
+		return hashAlgorithmDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.HashAlgorithm(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.HashAlgorithm enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum HashAlgorithm) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum HashAlgorithm) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = HashAlgorithm(0)
+	_ fmt.Stringer   = HashAlgorithm(0)
+)
+
+// KeyedHashAlgorithm identifies a keyed hash algorithm for use with
+// NewKeyedHashGenerator.
+type KeyedHashAlgorithm uint
+
+const (
+	_ KeyedHashAlgorithm = iota
+	KeyedHMACSHA256
+	KeyedBLAKE2b256
+)
+
+var keyedHashAlgorithmDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.KeyedHashAlgorithm(0)",
+		Name:   "keyed hash algorithm not specified",
+	},
+	{
+		GoName: "youyouayedee.KeyedHMACSHA256",
+		Name:   "HMAC-SHA-256",
+	},
+	{
+		GoName: "youyouayedee.KeyedBLAKE2b256",
+		Name:   "keyed BLAKE2b-256",
+	},
+}
+
+func (enum KeyedHashAlgorithm) IsValid() bool {
+	p := uint(enum)
+	q := uint(len(keyedHashAlgorithmDataArray))
+	return p > 0 && p < q
+}
+
+func (enum KeyedHashAlgorithm) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(keyedHashAlgorithmDataArray))
+	if p < q {
+		return keyedHashAlgorithmDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.KeyedHashAlgorithm(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.KeyedHashAlgorithm enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum KeyedHashAlgorithm) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum KeyedHashAlgorithm) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = KeyedHashAlgorithm(0)
+	_ fmt.Stringer   = KeyedHashAlgorithm(0)
+)
+
+// NameKind identifies the kind of name which a NameCanonicalizer rejected.
+type NameKind uint
+
+const (
+	_ NameKind = iota
+	NameDNS
+	NameURL
+	NameOID
+	NameX500
+)
+
+var nameKindDataArray = [...]EnumData{
+	{
+		GoName: "youyouayedee.NameKind(0)",
+		Name:   "name kind not specified",
+	},
+	{
+		GoName: "youyouayedee.NameDNS",
+		Name:   "DNS",
+	},
+	{
+		GoName: "youyouayedee.NameURL",
+		Name:   "URL",
+	},
+	{
+		GoName: "youyouayedee.NameOID",
+		Name:   "OID",
+	},
+	{
+		GoName: "youyouayedee.NameX500",
+		Name:   "X.500",
+	},
+}
+
+func (enum NameKind) Data() EnumData {
+	p := uint(enum)
+	q := uint(len(nameKindDataArray))
+	if p < q {
+		return nameKindDataArray[p]
+	}
+	goName := fmt.Sprintf("youyouayedee.NameKind(%d)", p)
+	name := fmt.Sprintf("<unspecified youyouayedee.NameKind enum constant %d>", p)
+	return EnumData{GoName: goName, Name: name}
+}
+
+func (enum NameKind) GoString() string {
+	return enum.Data().GoName
+}
+
+func (enum NameKind) String() string {
+	return enum.Data().Name
+}
+
+var (
+	_ fmt.GoStringer = NameKind(0)
+	_ fmt.Stringer   = NameKind(0)
+)
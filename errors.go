@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 // ErrClockNotFound indicates that the ClockStorage Load method was unable to
@@ -143,6 +144,32 @@ func (err ErrOperationFailed) Unwrap() error {
 
 var _ error = ErrOperationFailed{}
 
+// ErrLeapSecondDataNotValid indicates that a source of leap second data, such
+// as a "leap-seconds.list" file, could not be parsed or failed validation.
+type ErrLeapSecondDataNotValid struct {
+	Name    string
+	Line    uint
+	Message string
+}
+
+func (err ErrLeapSecondDataNotValid) Error() string {
+	buf := make([]byte, 0, 128)
+	buf = append(buf, "invalid leap second data"...)
+	if err.Name != "" {
+		buf = append(buf, ": "...)
+		buf = strconv.AppendQuote(buf, err.Name)
+	}
+	if err.Line != 0 {
+		buf = append(buf, ": line "...)
+		buf = strconv.AppendUint(buf, uint64(err.Line), 10)
+	}
+	buf = append(buf, ": "...)
+	buf = append(buf, err.Message...)
+	return string(buf)
+}
+
+var _ error = ErrLeapSecondDataNotValid{}
+
 // ErrParseFailed indicates that the input string could not be parsed as a
 // UUID.
 type ErrParseFailed struct {
@@ -192,3 +219,158 @@ func (err ErrParseFailed) Error() string {
 }
 
 var _ error = ErrParseFailed{}
+
+// ErrValidationFailed indicates that a UUID was rejected by a Validator.
+type ErrValidationFailed struct {
+	UUID UUID
+	Rule ValidationRule
+	Args []interface{}
+}
+
+func (err ErrValidationFailed) Error() string {
+	buf := make([]byte, 0, 128)
+	buf = append(buf, "UUID "...)
+	buf = err.UUID.AppendTo(buf)
+	buf = append(buf, " failed validation rule "...)
+	buf = strconv.AppendQuote(buf, err.Rule.String())
+	buf = append(buf, ": "...)
+	var formatted string
+	data := err.Rule.Data()
+	if data.Format == "" {
+		formatted = fmt.Sprintf("%#v", err.Args)
+	} else {
+		formatted = fmt.Sprintf(data.Format, err.Args...)
+	}
+	buf = append(buf, formatted...)
+	return string(buf)
+}
+
+var _ error = ErrValidationFailed{}
+
+// ErrEncodeFailed indicates that a Decoded value could not be encoded as a
+// UUID, because one of its fields is out of range.
+type ErrEncodeFailed struct {
+	Field   DecodedField
+	Message string
+}
+
+func (err ErrEncodeFailed) Error() string {
+	return fmt.Sprintf("failed to encode UUID: field %s: %s", err.Field, err.Message)
+}
+
+var _ error = ErrEncodeFailed{}
+
+// ErrV8LayoutNotValid indicates that a V8Layout is malformed, or that a value
+// supplied for one of its fields is out of range.
+type ErrV8LayoutNotValid struct {
+	Layout  string
+	Field   string
+	Message string
+}
+
+func (err ErrV8LayoutNotValid) Error() string {
+	buf := make([]byte, 0, 128)
+	buf = append(buf, "invalid V8 layout "...)
+	buf = strconv.AppendQuote(buf, err.Layout)
+	if err.Field != "" {
+		buf = append(buf, ": field "...)
+		buf = strconv.AppendQuote(buf, err.Field)
+	}
+	buf = append(buf, ": "...)
+	buf = append(buf, err.Message...)
+	return string(buf)
+}
+
+var _ error = ErrV8LayoutNotValid{}
+
+// ErrV8LayoutAmbiguous indicates that a V8Layout could not be registered,
+// because some UUIDs would match both it and an already registered layout.
+type ErrV8LayoutAmbiguous struct {
+	Layout   string
+	Existing string
+}
+
+func (err ErrV8LayoutAmbiguous) Error() string {
+	return fmt.Sprintf("V8 layout %q is ambiguous with registered layout %q; their constant fields do not distinguish them", err.Layout, err.Existing)
+}
+
+var _ error = ErrV8LayoutAmbiguous{}
+
+// ErrSequenceOverflow indicates that a V8 generator has already used every
+// counter value for the current timestamp tick.  The caller may
+// retry once the clock has advanced to the next tick.
+type ErrSequenceOverflow struct {
+	Layout string
+	Time   time.Time
+}
+
+func (err ErrSequenceOverflow) Error() string {
+	return fmt.Sprintf("sequence numbers for V8 layout %q are exhausted for the timestamp tick at %v", err.Layout, err.Time.UTC())
+}
+
+var _ error = ErrSequenceOverflow{}
+
+// ErrHashKeyNotValid indicates that a secret key cannot be used with the
+// requested keyed hash algorithm.
+type ErrHashKeyNotValid struct {
+	Algorithm KeyedHashAlgorithm
+	KeyID     byte
+	Message   string
+}
+
+func (err ErrHashKeyNotValid) Error() string {
+	return fmt.Sprintf("key %d is not valid for %v: %s", err.KeyID, err.Algorithm, err.Message)
+}
+
+var _ error = ErrHashKeyNotValid{}
+
+// ErrKeyedHashAlgorithmNotSupported indicates that NewKeyedHashGenerator does
+// not know the requested KeyedHashAlgorithm.
+type ErrKeyedHashAlgorithmNotSupported struct {
+	Algorithm KeyedHashAlgorithm
+}
+
+func (err ErrKeyedHashAlgorithmNotSupported) Error() string {
+	return fmt.Sprintf("keyed hash algorithm %#v is not supported", err.Algorithm)
+}
+
+var _ error = ErrKeyedHashAlgorithmNotSupported{}
+
+// ErrNameNotValid indicates that a name could not be canonicalized by a
+// NameCanonicalizer.
+type ErrNameNotValid struct {
+	Kind    NameKind
+	Name    string
+	Message string
+}
+
+func (err ErrNameNotValid) Error() string {
+	return fmt.Sprintf("invalid %s name %q: %s", err.Kind, err.Name, err.Message)
+}
+
+var _ error = ErrNameNotValid{}
+
+// ErrCipherInputNotValid indicates that a UUIDCipher was given a UUID which is
+// not an RFC 4122 variant UUID of the expected version.
+type ErrCipherInputNotValid struct {
+	UUID    UUID
+	Version Version
+}
+
+func (err ErrCipherInputNotValid) Error() string {
+	return fmt.Sprintf("UUID %v cannot be processed by this cipher; expected an RFC 4122 variant %v UUID", err.UUID, err.Version)
+}
+
+var _ error = ErrCipherInputNotValid{}
+
+// ErrSignatureNotValid indicates that a SignedUUID failed verification.
+type ErrSignatureNotValid struct {
+	KeyID   byte
+	Message string
+}
+
+func (err ErrSignatureNotValid) Error() string {
+	return fmt.Sprintf("signed UUID with key %d is not valid: %s", err.KeyID, err.Message)
+}
+
+var _ error = ErrSignatureNotValid{}
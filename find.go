@@ -0,0 +1,317 @@
+package youyouayedee
+
+import (
+	"io"
+)
+
+// maxMatchLen is the length of the longest text form recognized by the
+// finder, i.e. FormatURN.
+const maxMatchLen = 45
+
+// maxConsecutiveEmptyReads is the number of (0, nil) results from Read that
+// the Scanner tolerates before giving up with io.ErrNoProgress, as with
+// bufio.Scanner.
+const maxConsecutiveEmptyReads = 100
+
+// Match describes one occurrence of a UUID within a larger text.
+type Match struct {
+	// UUID is the UUID that was found.
+	UUID UUID
+
+	// Format is the text form in which the UUID was found: one of
+	// FormatCanonical, FormatBraced, FormatURN, or FormatHex.
+	Format Format
+
+	// Offset is the byte offset of the start of the occurrence, including
+	// any "{" or "urn:uuid:" prefix.
+	Offset int64
+
+	// Length is the length of the occurrence in bytes.
+	Length int
+}
+
+// End returns the byte offset just past the end of the occurrence.
+func (m Match) End() int64 {
+	return m.Offset + int64(m.Length)
+}
+
+// FindOptions supplies options for locating UUIDs within free text.
+//
+// Occurrences must be delimited by word boundaries: the bytes immediately
+// before and after an occurrence must not be ASCII letters, digits, or
+// underscores.  For example, the 64 hex digits of a SHA-256 hash do not
+// contain a FormatHex occurrence.
+//
+// Upper and lower case hex digits are both accepted, as is a "urn:uuid:"
+// prefix in any case.
+//
+type FindOptions struct {
+	// Formats lists the text forms to look for.  If this field is empty,
+	// then FormatCanonical, FormatBraced, FormatURN, and FormatHex are all
+	// recognized.  Other formats are ignored.
+	//
+	// If FormatURN is not listed but FormatCanonical is, then the
+	// canonical part of a URN is still found.
+	//
+	Formats []Format
+
+	// Versions lists the UUID versions to accept.  If this field is
+	// non-empty, then only RFC 4122 variant UUIDs with one of the listed
+	// versions are reported.
+	Versions []Version
+
+	// Variants lists the UUID variants to accept.  If this field is empty,
+	// then UUIDs of all variants are reported.
+	Variants []Variant
+}
+
+// FindAll returns every UUID occurrence in the given text, using the default
+// FindOptions.
+//
+func FindAll(data []byte) []Match {
+	return FindOptions{}.FindAll(data)
+}
+
+// FindAll returns every UUID occurrence in the given text, in order of
+// increasing offset.  Occurrences do not overlap.
+//
+func (opts FindOptions) FindAll(data []byte) []Match {
+	var out []Match
+	dataLen := len(data)
+	for pos := 0; pos < dataLen; {
+		m, ok := opts.matchAt(data, pos)
+		if !ok {
+			pos++
+			continue
+		}
+		out = append(out, m)
+		pos += m.Length
+	}
+	return out
+}
+
+// Scanner locates UUIDs in text read from an io.Reader.  Its API mirrors that
+// of "bufio".Scanner:
+//
+//	s := youyouayedee.NewScanner(r, youyouayedee.FindOptions{})
+//	for s.Scan() {
+//		m := s.Match()
+//		// ...
+//	}
+//	if err := s.Err(); err != nil {
+//		// ...
+//	}
+//
+type Scanner struct {
+	r     io.Reader
+	opts  FindOptions
+	buf   []byte
+	base  int64
+	pos   int
+	eof   bool
+	err   error
+	match Match
+}
+
+// NewScanner constructs a new Scanner which reads from r.
+func NewScanner(r io.Reader, opts FindOptions) *Scanner {
+	return &Scanner{
+		r:    r,
+		opts: opts,
+		buf:  make([]byte, 0, 4096),
+	}
+}
+
+// Scan advances to the next UUID occurrence, which is then available through
+// the Match method.  It returns false when the input is exhausted or when an
+// error occurs; the Err method distinguishes the two cases.
+//
+func (s *Scanner) Scan() bool {
+	for {
+		// Matching at pos may need to look at up to one byte past the
+		// longest possible occurrence, to check for a word boundary.
+		for s.pos < len(s.buf) && (s.eof || len(s.buf)-s.pos > maxMatchLen) {
+			m, ok := s.opts.matchAt(s.buf, s.pos)
+			if !ok {
+				s.pos++
+				continue
+			}
+			m.Offset += s.base
+			s.match = m
+			s.pos += m.Length
+			return true
+		}
+
+		if s.eof || s.err != nil {
+			return false
+		}
+		s.fill()
+	}
+}
+
+// Match returns the most recent occurrence found by Scan.
+func (s *Scanner) Match() Match {
+	return s.match
+}
+
+// Err returns the first non-EOF error encountered while reading.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+func (s *Scanner) fill() {
+	// Keep the byte before pos, which is needed to check for a word
+	// boundary at pos.
+	keep := s.pos - 1
+	if keep > 0 {
+		n := copy(s.buf, s.buf[keep:])
+		s.buf = s.buf[:n]
+		s.base += int64(keep)
+		s.pos -= keep
+	}
+
+	for tries := 0; tries < maxConsecutiveEmptyReads; tries++ {
+		n, err := s.r.Read(s.buf[len(s.buf):cap(s.buf)])
+		s.buf = s.buf[:len(s.buf)+n]
+		if err == io.EOF {
+			s.eof = true
+			return
+		} else if err != nil {
+			s.err = err
+			s.eof = true
+			return
+		} else if n > 0 {
+			return
+		}
+	}
+	s.err = io.ErrNoProgress
+	s.eof = true
+}
+
+// matchAt checks for a UUID occurrence starting at data[pos].  The end of data
+// is treated as a word boundary, so unless the true end of the input has been
+// reached, data must extend more than maxMatchLen bytes past pos.
+func (opts FindOptions) matchAt(data []byte, pos int) (Match, bool) {
+	if pos > 0 && isWordByte(data[pos-1]) {
+		return Match{}, false
+	}
+
+	rest := data[pos:]
+	restLen := len(rest)
+	ch := rest[0]
+
+	// ParseOptions applies the same rule to Formats: empty means all.
+	formats := ParseOptions{Formats: opts.Formats}
+
+	var m Match
+	var ok bool
+	switch {
+	case ch == 'u' || ch == 'U':
+		if restLen >= 45 && formats.allowsFormat(FormatURN) && isURNPrefix(rest) {
+			m.UUID, ok = decodeFoundHex(rest[9:45], true)
+			m.Format = FormatURN
+			m.Length = 45
+			ok = ok && isBoundaryAt(rest, 45)
+		}
+
+	case ch == '{':
+		if restLen >= 38 && formats.allowsFormat(FormatBraced) && rest[37] == '}' {
+			m.UUID, ok = decodeFoundHex(rest[1:37], true)
+			m.Format = FormatBraced
+			m.Length = 38
+			ok = ok && isBoundaryAt(rest, 38)
+		}
+
+	case hexDecode[ch] < 0x10:
+		if restLen >= 36 && rest[8] == '-' && formats.allowsFormat(FormatCanonical) {
+			m.UUID, ok = decodeFoundHex(rest[0:36], true)
+			m.Format = FormatCanonical
+			m.Length = 36
+			ok = ok && isBoundaryAt(rest, 36)
+		} else if restLen >= 32 && formats.allowsFormat(FormatHex) {
+			m.UUID, ok = decodeFoundHex(rest[0:32], false)
+			m.Format = FormatHex
+			m.Length = 32
+			ok = ok && isBoundaryAt(rest, 32)
+		}
+	}
+
+	if !ok || !opts.accepts(m.UUID) {
+		return Match{}, false
+	}
+	m.Offset = int64(pos)
+	return m, true
+}
+
+func (opts FindOptions) accepts(uuid UUID) bool {
+	variant := uuid.Variant()
+	if len(opts.Variants) != 0 {
+		found := false
+		for _, allowed := range opts.Variants {
+			if allowed == variant {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(opts.Versions) != 0 {
+		if variant != VariantRFC4122 {
+			return false
+		}
+		version := uuid.Version()
+		for _, allowed := range opts.Versions {
+			if allowed == version {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// decodeFoundHex decodes 32 hex digits, or 36 characters of hex digits and
+// dashes in the canonical layout.
+func decodeFoundHex(input []byte, dashes bool) (UUID, bool) {
+	var uuid UUID
+	ii := uint(0)
+	for bi := uint(0); bi < Size; bi++ {
+		if dashes && (bi == 4 || bi == 6 || bi == 8 || bi == 10) {
+			if input[ii] != '-' {
+				return Nil, false
+			}
+			ii++
+		}
+		ok, value := decodeHexByte(input, ii)
+		if !ok {
+			return Nil, false
+		}
+		uuid[bi] = value
+		ii += 2
+	}
+	return uuid, true
+}
+
+func isURNPrefix(input []byte) bool {
+	const prefix = "urn:uuid:"
+	for ii := 0; ii < len(prefix); ii++ {
+		ch := input[ii]
+		if ch >= 'A' && ch <= 'Z' {
+			ch += 'a' - 'A'
+		}
+		if ch != prefix[ii] {
+			return false
+		}
+	}
+	return true
+}
+
+func isBoundaryAt(input []byte, ii int) bool {
+	return ii >= len(input) || !isWordByte(input[ii])
+}
+
+func isWordByte(ch byte) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || ch == '_'
+}
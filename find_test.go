@@ -0,0 +1,125 @@
+package youyouayedee
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const testFindInput = "" +
+	"req=d3ef7600-6a95-11ec-9234-2358840c40e6 " +
+	"guid={D3EF7600-6A95-11EC-9234-2358840C40E6}\n" +
+	"urn=URN:UUID:017e0a4f-6c8d-7d4e-9b8c-0123456789ab, " +
+	"hex=d3ef76006a9511ec92342358840c40e6;" +
+	"sha=d3ef76006a9511ec92342358840c40e6d3ef76006a9511ec92342358840c40e6 " +
+	"word=xd3ef7600-6a95-11ec-9234-2358840c40e6 " +
+	"ncs=333a2276-0000-0000-0d00-00809c000000"
+
+func TestFindAll(t *testing.T) {
+	type testRow struct {
+		Name    string
+		Options FindOptions
+		Expect  []Match
+	}
+
+	v1 := Must(Parse("d3ef7600-6a95-11ec-9234-2358840c40e6"))
+	v7 := Must(Parse("017e0a4f-6c8d-7d4e-9b8c-0123456789ab"))
+	ncs := Must(ParseOptions{AcceptAnyVariant: true}.Parse("333a2276-0000-0000-0d00-00809c000000"))
+
+	all := []Match{
+		{UUID: v1, Format: FormatCanonical, Offset: 4, Length: 36},
+		{UUID: v1, Format: FormatBraced, Offset: 46, Length: 38},
+		{UUID: v7, Format: FormatURN, Offset: 89, Length: 45},
+		{UUID: v1, Format: FormatHex, Offset: 140, Length: 32},
+		{UUID: ncs, Format: FormatCanonical, Offset: 289, Length: 36},
+	}
+
+	testData := [...]testRow{
+		{Name: "default", Expect: all},
+		{Name: "v7", Options: FindOptions{Versions: []Version{7}}, Expect: all[2:3]},
+		{Name: "ncs", Options: FindOptions{Variants: []Variant{VariantNCS}}, Expect: all[4:5]},
+		{
+			Name:    "canonical",
+			Options: FindOptions{Formats: []Format{FormatCanonical}},
+			Expect: []Match{
+				all[0],
+				{UUID: v1, Format: FormatCanonical, Offset: 47, Length: 36},
+				{UUID: v7, Format: FormatCanonical, Offset: 98, Length: 36},
+				all[4],
+			},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			input := []byte(testFindInput)
+			actual := row.Options.FindAll(input)
+			compareMatches(t, "FindAll", row.Expect, actual)
+			for _, m := range actual {
+				text := string(input[m.Offset:m.End()])
+				compare[int](t, "Length", m.Format.Len(), len(text))
+			}
+
+			var scanned []Match
+			s := NewScanner(iotest.OneByteReader(strings.NewReader(testFindInput)), row.Options)
+			for s.Scan() {
+				scanned = append(scanned, s.Match())
+			}
+			compareError(t, "Err", nil, s.Err())
+			compareMatches(t, "Scanner", row.Expect, scanned)
+		})
+	}
+}
+
+func TestScanner_LargeInput(t *testing.T) {
+	var buf bytes.Buffer
+	var expect []Match
+	for index := 0; index < 1000; index++ {
+		buf.WriteString("some filler text, ")
+		expect = append(expect, Match{UUID: uuidV1, Format: FormatCanonical, Offset: int64(buf.Len()), Length: 36})
+		buf.WriteString(uuidV1.String())
+		buf.WriteString("\n")
+	}
+
+	var actual []Match
+	s := NewScanner(bytes.NewReader(buf.Bytes()), FindOptions{})
+	for s.Scan() {
+		actual = append(actual, s.Match())
+	}
+	compareError(t, "Err", nil, s.Err())
+	compareMatches(t, "Scanner", expect, actual)
+}
+
+func TestScanner_Error(t *testing.T) {
+	errTest := errors.New("test error")
+	r := io.MultiReader(strings.NewReader(uuidV1.String()+" "), iotest.ErrReader(errTest))
+	s := NewScanner(r, FindOptions{})
+	compare[bool](t, "Scan", true, s.Scan())
+	compare[UUID](t, "UUID", uuidV1, s.Match().UUID)
+	compare[bool](t, "Scan", false, s.Scan())
+	compareError(t, "Err", errTest, s.Err())
+}
+
+type emptyReader struct{}
+
+func (emptyReader) Read(p []byte) (int, error) { return 0, nil }
+
+func TestScanner_NoProgress(t *testing.T) {
+	r := io.MultiReader(strings.NewReader(uuidV1.String()+" "), emptyReader{})
+	s := NewScanner(r, FindOptions{})
+	compare[bool](t, "Scan", true, s.Scan())
+	compare[UUID](t, "UUID", uuidV1, s.Match().UUID)
+	compare[bool](t, "Scan", false, s.Scan())
+	compareError(t, "Err", io.ErrNoProgress, s.Err())
+}
+
+func compareMatches(t *testing.T, name string, expect []Match, actual []Match) {
+	t.Helper()
+	compare[int](t, name+"/len", len(expect), len(actual))
+	for index := 0; index < len(expect) && index < len(actual); index++ {
+		compare[Match](t, name, expect[index], actual[index])
+	}
+}
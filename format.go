@@ -0,0 +1,244 @@
+package youyouayedee
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// Len returns the length in bytes of a UUID in this format.
+//
+// Unknown formats are treated as FormatCanonical.
+//
+func (format Format) Len() int {
+	switch format {
+	case FormatHex:
+		return 32
+	case FormatBraced:
+		return 38
+	case FormatURN:
+		return 45
+	case FormatBinary:
+		return Size
+	case FormatULID:
+		return ULIDLength
+	default:
+		return 36
+	}
+}
+
+// Append appends the given UUID to the given []byte in this format, using
+// lower case hex digits.  It does not allocate if out has enough capacity.
+//
+// Unknown formats are treated as FormatCanonical.
+//
+func (format Format) Append(out []byte, uuid UUID) []byte {
+	return format.appendImpl(out, uuid, hexEncode)
+}
+
+// AppendUpper is like Append, but it uses upper case hex digits.  Note that
+// FormatURN still uses a lower case "urn:uuid:" prefix, as recommended by
+// RFC 4122.
+//
+// FormatULID always uses upper case, so Append and AppendUpper are identical
+// for that format.
+//
+func (format Format) AppendUpper(out []byte, uuid UUID) []byte {
+	return format.appendImpl(out, uuid, hexEncodeUpper)
+}
+
+func (format Format) appendImpl(out []byte, uuid UUID, table string) []byte {
+	switch format {
+	case FormatHex:
+		return appendHexUUID(out, uuid, false, table)
+
+	case FormatBraced:
+		out = append(out, '{')
+		out = appendHexUUID(out, uuid, true, table)
+		return append(out, '}')
+
+	case FormatURN:
+		out = append(out, "urn:uuid:"...)
+		return appendHexUUID(out, uuid, true, table)
+
+	case FormatBinary:
+		return append(out, uuid[:]...)
+
+	case FormatULID:
+		return uuid.AppendULID(out)
+
+	default:
+		return appendHexUUID(out, uuid, true, table)
+	}
+}
+
+// Format fulfills the "fmt".Formatter interface.
+//
+// The verbs %s and %v produce the canonical form, %x produces 32 lower case
+// hex digits without dashes, %X produces 32 upper case hex digits without
+// dashes, and %q produces the canonical form as a double-quoted string.
+//
+// For %s, %q, %x, and %X, the '#' flag selects the braced form and the '+'
+// flag selects the URN form.  %#v produces the same output as GoString, and
+// %+v produces the canonical form, so that struct dumps with %+v are not
+// affected.  The width and '-' flags pad the output with spaces as usual.
+//
+// Format does not allocate, except when reporting an unsupported verb.
+//
+func (uuid UUID) Format(f fmt.State, verb rune) {
+	format := FormatCanonical
+	pairs := hexPairs
+	formFlags := true
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			uuid.writeGoString(f)
+			return
+		}
+		formFlags = false
+	case 's', 'q':
+		// pass
+	case 'x':
+		format = FormatHex
+	case 'X':
+		format = FormatHex
+		pairs = hexPairsUpper
+	default:
+		var tmp [64]byte
+		buf := append(tmp[:0], '%', '!')
+		buf = utf8.AppendRune(buf, verb)
+		buf = append(buf, "(youyouayedee.UUID="...)
+		buf = uuid.AppendTo(buf)
+		buf = append(buf, ')')
+		_, _ = f.Write(buf)
+		return
+	}
+
+	if formFlags && f.Flag('+') {
+		format = FormatURN
+	} else if formFlags && f.Flag('#') {
+		format = FormatBraced
+	}
+
+	length := format.Len()
+	if verb == 'q' {
+		length += 2
+	}
+
+	width, hasWidth := f.Width()
+	pad := 0
+	if hasWidth && width > length {
+		pad = width - length
+	}
+
+	if pad > 0 && !f.Flag('-') {
+		writeSpaces(f, pad)
+	}
+	if verb == 'q' {
+		writeString(f, "\"")
+	}
+	switch format {
+	case FormatHex:
+		writeHexUUID(f, uuid, false, pairs)
+	case FormatBraced:
+		writeString(f, "{")
+		writeHexUUID(f, uuid, true, pairs)
+		writeString(f, "}")
+	case FormatURN:
+		writeString(f, "urn:uuid:")
+		writeHexUUID(f, uuid, true, pairs)
+	default:
+		writeHexUUID(f, uuid, true, pairs)
+	}
+	if verb == 'q' {
+		writeString(f, "\"")
+	}
+	if pad > 0 && f.Flag('-') {
+		writeSpaces(f, pad)
+	}
+}
+
+var _ fmt.Formatter = UUID{}
+
+// hexPairs and hexPairsUpper hold the two hex digits for each byte value, so
+// that Format can write slices of them without building a buffer.
+var (
+	hexPairs      = makeHexPairs(hexEncode)
+	hexPairsUpper = makeHexPairs(hexEncodeUpper)
+)
+
+func makeHexPairs(table string) string {
+	var tmp [512]byte
+	for bi := uint(0); bi < 256; bi++ {
+		tmp[2*bi] = table[bi>>4]
+		tmp[2*bi+1] = table[bi&0xf]
+	}
+	return string(tmp[:])
+}
+
+// writeString writes str to f.  It does not allocate as long as f implements
+// io.StringWriter, as the fmt package's implementation of fmt.State does.
+func writeString(f fmt.State, str string) {
+	if sw, ok := f.(io.StringWriter); ok {
+		_, _ = sw.WriteString(str)
+		return
+	}
+	_, _ = f.Write([]byte(str))
+}
+
+func writeHexUUID(f fmt.State, uuid UUID, dashes bool, pairs string) {
+	for bi := uint(0); bi < Size; bi++ {
+		if dashes && (bi == 4 || bi == 6 || bi == 8 || bi == 10) {
+			writeString(f, "-")
+		}
+		value := uint(uuid[bi])
+		writeString(f, pairs[2*value:2*value+2])
+	}
+}
+
+func (uuid UUID) writeGoString(f fmt.State) {
+	if uuid.IsZero() {
+		writeString(f, "youyouayedee.Nil")
+		return
+	}
+
+	if uuid.IsMax() {
+		writeString(f, "youyouayedee.Max")
+		return
+	}
+
+	writeString(f, "youyouayedee.UUID{")
+	for bi := uint(0); bi < Size; bi++ {
+		if bi == 0 {
+			writeString(f, "0x")
+		} else {
+			writeString(f, ", 0x")
+		}
+		value := uint(uuid[bi])
+		writeString(f, hexPairs[2*value:2*value+2])
+	}
+	writeString(f, "}")
+}
+
+func appendHexUUID(out []byte, uuid UUID, dashes bool, table string) []byte {
+	for bi := uint(0); bi < Size; bi++ {
+		if dashes && (bi == 4 || bi == 6 || bi == 8 || bi == 10) {
+			out = append(out, '-')
+		}
+		value := uuid[bi]
+		out = append(out, table[value>>4], table[value&0xf])
+	}
+	return out
+}
+
+func writeSpaces(f fmt.State, count int) {
+	const spaces = "                "
+	for count > 0 {
+		n := count
+		if n > len(spaces) {
+			n = len(spaces)
+		}
+		writeString(f, spaces[:n])
+		count -= n
+	}
+}
@@ -1,5 +1,9 @@
 package youyouayedee
 
+import (
+	"io"
+)
+
 // Generator is an interface for generating new UUID values.
 type Generator interface {
 	// NewUUID generates a new unpredictable UUID.
@@ -18,6 +22,59 @@ type Generator interface {
 	NewHashUUID(data []byte) (UUID, error)
 }
 
+// HashGenerator is an interface for Generators that accept structured or
+// streaming input when generating deterministic UUIDs.  The Generators
+// returned by NewHashGenerator implement this interface.
+//
+type HashGenerator interface {
+	Generator
+
+	// NewHashUUIDFromReader generates a new deterministic UUID by hashing
+	// all data read from r until EOF.  The result is the same as calling
+	// NewHashUUID with the same data, but the data is never buffered in
+	// full.
+	//
+	// Generators are not required to support this operation, and should
+	// return ErrMethodNotSupported{MethodNewHashUUIDFromReader} if it is
+	// not.
+	//
+	NewHashUUIDFromReader(r io.Reader) (UUID, error)
+
+	// NewHashUUIDFromParts generates a new deterministic UUID by hashing
+	// the given components, each prefixed by its length.  Unlike
+	// concatenating the components, this ensures that ("ab", "c") and
+	// ("a", "bc") produce different UUIDs.
+	//
+	// Generators are not required to support this operation, and should
+	// return ErrMethodNotSupported{MethodNewHashUUIDFromParts} if it is
+	// not.
+	//
+	NewHashUUIDFromParts(parts ...[]byte) (UUID, error)
+
+	// NewHashUUIDInNamespace generates a new deterministic UUID by hashing
+	// the given input data in the given namespace, instead of the
+	// namespace supplied when the Generator was constructed.
+	//
+	// Generators are not required to support this operation, and should
+	// return ErrMethodNotSupported{MethodNewHashUUIDInNamespace} if it is
+	// not.
+	//
+	NewHashUUIDInNamespace(ns UUID, data []byte) (UUID, error)
+
+	// NewHashUUIDFromPath derives a UUID from a sequence of names by
+	// successive namespacing: the first name is hashed in the Generator's
+	// namespace, and each later name is hashed in the namespace of the
+	// UUID derived so far.  For example, the path ("org", "project")
+	// yields NewHashUUIDInNamespace(NewHashUUID("org"), "project").
+	// An empty path yields the Generator's namespace itself.
+	//
+	// Generators are not required to support this operation, and should
+	// return ErrMethodNotSupported{MethodNewHashUUIDFromPath} if it is
+	// not.
+	//
+	NewHashUUIDFromPath(names ...[]byte) (UUID, error)
+}
+
 // NewGenerator initializes a new Generator instance for the given UUID version.
 //
 // If this library does not know how to generate UUIDs of the given version,
@@ -64,7 +121,26 @@ func (GeneratorBase) NewHashUUID(data []byte) (UUID, error) {
 	return Nil, ErrMethodNotSupported{Method: MethodNewHashUUID}
 }
 
-var _ Generator = GeneratorBase{}
+func (GeneratorBase) NewHashUUIDFromReader(r io.Reader) (UUID, error) {
+	return Nil, ErrMethodNotSupported{Method: MethodNewHashUUIDFromReader}
+}
+
+func (GeneratorBase) NewHashUUIDFromParts(parts ...[]byte) (UUID, error) {
+	return Nil, ErrMethodNotSupported{Method: MethodNewHashUUIDFromParts}
+}
+
+func (GeneratorBase) NewHashUUIDInNamespace(ns UUID, data []byte) (UUID, error) {
+	return Nil, ErrMethodNotSupported{Method: MethodNewHashUUIDInNamespace}
+}
+
+func (GeneratorBase) NewHashUUIDFromPath(names ...[]byte) (UUID, error) {
+	return Nil, ErrMethodNotSupported{Method: MethodNewHashUUIDFromPath}
+}
+
+var (
+	_ Generator     = GeneratorBase{}
+	_ HashGenerator = GeneratorBase{}
+)
 
 // GeneratorFactory is an interface for constructing Generator instances.
 //
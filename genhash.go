@@ -3,7 +3,9 @@ package youyouayedee
 import (
 	"crypto/md5"
 	"crypto/sha1"
+	"encoding/binary"
 	"hash"
+	"io"
 	"sync/atomic"
 )
 
@@ -11,7 +13,13 @@ import (
 //
 // Versions 3, 5, and 8 are supported.  Options must specify a valid, non-nil
 // UUID in the Namespace field.  For version 8 only, Options must also specify
-// a valid, non-nil HashFactory callback.
+// either a valid, non-nil HashFactory callback or a valid HashAlgorithm.
+//
+// Version 8 UUIDs are built as described in RFC 9562 Appendix B: the
+// namespace and the name are hashed together, exactly as for versions 3 and
+// 5, and the first 128 bits of the digest are kept.
+//
+// The returned Generator also implements HashGenerator.
 //
 func NewHashGenerator(version Version, o Options) (Generator, error) {
 	var factory func() hash.Hash
@@ -24,6 +32,9 @@ func NewHashGenerator(version Version, o Options) (Generator, error) {
 
 	case 8:
 		factory = o.HashFactory
+		if factory == nil {
+			factory = o.HashAlgorithm.Factory()
+		}
 		if factory == nil {
 			return nil, ErrHashFactoryIsNil{Version: version}
 		}
@@ -38,7 +49,11 @@ func NewHashGenerator(version Version, o Options) (Generator, error) {
 	}
 
 	h := factory()
-	return &genHash{ns: ns, fn: factory, ver: version, busy: 0, hasher: h}, nil
+	g := &genHash{ns: ns, fn: factory, ver: version, busy: 0, hasher: h, canonicalize: o.CanonicalizeNames}
+	if o.CanonicalizeNames {
+		g.canon = CanonicalizerForNamespace(ns)
+	}
+	return g, nil
 }
 
 type genHash struct {
@@ -49,30 +64,154 @@ type genHash struct {
 	ver    Version
 	busy   uintptr
 	hasher hash.Hash
+
+	// keyed is true for generators built by NewKeyedHashGenerator, which
+	// place keyID in the first byte of every UUID.
+	keyed bool
+	keyID byte
+
+	// canon, if non-nil, is applied to names before hashing.
+	canon NameCanonicalizer
+
+	// canonicalize is true if names passed to NewHashUUIDInNamespace are
+	// canonicalized when their namespace is a well-known one.
+	canonicalize bool
 }
 
 func (g *genHash) NewHashUUID(data []byte) (UUID, error) {
-	var uuid UUID
-	if atomic.CompareAndSwapUintptr(&g.busy, 0, 1) {
-		uuid = hashImpl(g.hasher, g.ver, g.ns, data)
-		atomic.StoreUintptr(&g.busy, 0)
-	} else {
-		h := g.fn()
-		uuid = hashImpl(h, g.ver, g.ns, data)
+	if g.canon != nil {
+		name, err := g.canon(string(data))
+		if err != nil {
+			return Nil, err
+		}
+		data = []byte(name)
+	}
+	return g.hash(func(h hash.Hash) error {
+		_, _ = h.Write(data)
+		return nil
+	})
+}
+
+func (g *genHash) NewHashUUIDFromReader(r io.Reader) (UUID, error) {
+	if g.canon != nil {
+		// Names are short, and must be seen in full to be canonicalized.
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return Nil, ErrOperationFailed{Operation: ReadHashInputOp, Err: err}
+		}
+		return g.NewHashUUID(data)
+	}
+	return g.hash(func(h hash.Hash) error {
+		if _, err := io.Copy(h, r); err != nil {
+			return ErrOperationFailed{Operation: ReadHashInputOp, Err: err}
+		}
+		return nil
+	})
+}
+
+func (g *genHash) NewHashUUIDFromParts(parts ...[]byte) (UUID, error) {
+	return g.hash(func(h hash.Hash) error {
+		writeHashParts(h, parts)
+		return nil
+	})
+}
+
+func (g *genHash) NewHashUUIDInNamespace(ns UUID, data []byte) (UUID, error) {
+	if ns == g.ns {
+		return g.NewHashUUID(data)
+	}
+	if !ns.IsValid() {
+		return Nil, ErrNamespaceNotValid{Version: g.ver, Namespace: ns}
+	}
+	if g.canonicalize {
+		if canon := CanonicalizerForNamespace(ns); canon != nil {
+			name, err := canon(string(data))
+			if err != nil {
+				return Nil, err
+			}
+			data = []byte(name)
+		}
+	}
+	return g.hashIn(ns, func(h hash.Hash) error {
+		_, _ = h.Write(data)
+		return nil
+	})
+}
+
+func (g *genHash) NewHashUUIDFromPath(names ...[]byte) (UUID, error) {
+	// Each derived namespace is itself a hash-based UUID, so only the
+	// first name can be in a well-known namespace and be canonicalized.
+	uuid := g.ns
+	for _, name := range names {
+		var err error
+		uuid, err = g.NewHashUUIDInNamespace(uuid, name)
+		if err != nil {
+			return Nil, err
+		}
 	}
 	return uuid, nil
 }
 
-func hashImpl(h hash.Hash, v Version, ns UUID, data []byte) UUID {
+// hash runs fn with a reset hasher that has already consumed the namespace.
+func (g *genHash) hash(fn func(h hash.Hash) error) (UUID, error) {
+	return g.hashIn(g.ns, fn)
+}
+
+// hashIn is like hash, but for the given namespace.  The shared hasher is
+// used when it is idle; otherwise a fresh one is made.
+func (g *genHash) hashIn(ns UUID, fn func(h hash.Hash) error) (UUID, error) {
+	h := g.hasher
+	if g.acquire() {
+		defer g.release()
+	} else {
+		h = g.fn()
+	}
+
 	h.Reset()
 	_, _ = h.Write(ns[:])
-	_, _ = h.Write(data)
-	s := h.Sum(nil)
+	if err := fn(h); err != nil {
+		return Nil, err
+	}
+	return g.result(h), nil
+}
+
+// acquire returns true iff it claimed the shared hasher, in which case the
+// caller must call release when done with it.
+func (g *genHash) acquire() bool {
+	return atomic.CompareAndSwapUintptr(&g.busy, 0, 1)
+}
+
+func (g *genHash) release() {
+	atomic.StoreUintptr(&g.busy, 0)
+}
+
+// writeHashParts writes each part to h, prefixed by its length as a 64-bit
+// big-endian integer.
+func writeHashParts(h hash.Hash, parts [][]byte) {
+	var prefix [8]byte
+	for _, part := range parts {
+		binary.BigEndian.PutUint64(prefix[:], uint64(len(part)))
+		_, _ = h.Write(prefix[:])
+		_, _ = h.Write(part)
+	}
+}
+
+func (g *genHash) result(h hash.Hash) UUID {
+	var buf [64]byte
+	s := h.Sum(buf[:0])
 	var uuid UUID
-	copy(uuid[:], s)
+	if g.keyed {
+		uuid[0] = g.keyID
+		copy(uuid[1:], s)
+	} else {
+		copy(uuid[:], s)
+	}
 	uuid[8] = (uuid[8] & 0x3f) | 0x80
-	uuid[6] = (uuid[6] & 0x0f) | byte(v<<4)
+	uuid[6] = (uuid[6] & 0x0f) | byte(g.ver<<4)
 	return uuid
 }
 
-var _ Generator = (*genHash)(nil)
+var (
+	_ Generator     = (*genHash)(nil)
+	_ HashGenerator = (*genHash)(nil)
+)
@@ -0,0 +1,144 @@
+package youyouayedee
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNamespaces(t *testing.T) {
+	compare[string](t, "NamespaceDNS", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", NamespaceDNS.String())
+	compare[string](t, "NamespaceURL", "6ba7b811-9dad-11d1-80b4-00c04fd430c8", NamespaceURL.String())
+	compare[string](t, "NamespaceOID", "6ba7b812-9dad-11d1-80b4-00c04fd430c8", NamespaceOID.String())
+	compare[string](t, "NamespaceX500", "6ba7b814-9dad-11d1-80b4-00c04fd430c8", NamespaceX500.String())
+}
+
+func TestHashAlgorithm(t *testing.T) {
+	compare[string](t, "HashSpace", "3fb32780-953c-4464-9cfd-e85dbbe9843d", HashSHA256.HashSpace().String())
+	compare[string](t, "HashSpace", "a4920a5d-a8a6-426c-8d14-a6cafbe64c7b", HashSHA3_512.HashSpace().String())
+	compare[UUID](t, "HashSpace", Nil, HashAlgorithm(0).HashSpace())
+	compare[bool](t, "Factory", true, HashAlgorithm(99).Factory() == nil)
+
+	for algo := HashSHA224; algo <= HashSHA3_512; algo++ {
+		found, ok := HashAlgorithmForSpace(algo.HashSpace())
+		compare[bool](t, algo.String(), true, ok)
+		compare[HashAlgorithm](t, algo.String(), algo, found)
+	}
+
+	_, ok := HashAlgorithmForSpace(Nil)
+	compare[bool](t, "HashAlgorithmForSpace(Nil)", false, ok)
+}
+
+func TestNewHashGenerator(t *testing.T) {
+	type testRow struct {
+		Name    string
+		Version Version
+		Options Options
+		Expect  string
+	}
+
+	// Test vectors for versions 3, 5, and 8 (SHA-256) are from RFC 9562
+	// Appendices A.2, A.4, and B.2.
+	testData := [...]testRow{
+		{"V3", 3, Options{Namespace: NamespaceDNS}, "5df41881-3aed-3515-88a7-2f4a814cf09e"},
+		{"V5", 5, Options{Namespace: NamespaceDNS}, "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		{"V8/SHA-256", 8, Options{Namespace: NamespaceDNS, HashAlgorithm: HashSHA256}, "5c146b14-3c52-8afd-938a-375d0df1fbf6"},
+		{"V8/SHA-512", 8, Options{Namespace: NamespaceDNS, HashAlgorithm: HashSHA512}, "94ee4ddb-9f36-8018-9ccf-86a4441691e0"},
+		{"V8/SHA3-256", 8, Options{Namespace: NamespaceDNS, HashAlgorithm: HashSHA3_256}, "fc506eca-a1f4-8315-87c8-c71449dfd324"},
+		{"V8/HashFactory", 8, Options{Namespace: NamespaceDNS, HashFactory: sha256.New, HashAlgorithm: HashSHA512}, "5c146b14-3c52-8afd-938a-375d0df1fbf6"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			g, err := NewHashGenerator(row.Version, row.Options)
+			if err != nil {
+				t.Fatalf("NewHashGenerator: unexpected error: %v", err)
+			}
+			uuid, err := g.NewHashUUID([]byte("www.example.com"))
+			compareError(t, "NewHashUUID", nil, err)
+			compare[string](t, "NewHashUUID", row.Expect, uuid.String())
+		})
+	}
+
+	_, err := NewHashGenerator(8, Options{Namespace: NamespaceDNS})
+	compareError(t, "NewHashGenerator/nil", ErrHashFactoryIsNil{Version: 8}, err)
+}
+
+func TestHashGenerator_Streaming(t *testing.T) {
+	g, err := NewHashGenerator(5, Options{Namespace: NamespaceDNS})
+	if err != nil {
+		t.Fatalf("NewHashGenerator: unexpected error: %v", err)
+	}
+	hg, ok := g.(HashGenerator)
+	if !ok {
+		t.Fatalf("NewHashGenerator: %T does not implement HashGenerator", g)
+	}
+
+	uuid, err := hg.NewHashUUIDFromReader(iotest.OneByteReader(strings.NewReader("www.example.com")))
+	compareError(t, "NewHashUUIDFromReader", nil, err)
+	compare[string](t, "NewHashUUIDFromReader", "2ed6657d-e927-568b-95e1-2665a8aea6a2", uuid.String())
+
+	errBoom := errors.New("boom")
+	_, err = hg.NewHashUUIDFromReader(iotest.ErrReader(errBoom))
+	compareError(t, "NewHashUUIDFromReader/error", ErrOperationFailed{Operation: ReadHashInputOp, Err: errBoom}, err)
+
+	uuid, err = hg.NewHashUUIDFromParts([]byte("tenant"), []byte("table"), []byte("key"))
+	compareError(t, "NewHashUUIDFromParts", nil, err)
+	compare[string](t, "NewHashUUIDFromParts", "d1113c38-2b29-53d1-b9a4-ea9ddb01d10c", uuid.String())
+
+	ab, _ := hg.NewHashUUIDFromParts([]byte("ab"), []byte("c"))
+	bc, _ := hg.NewHashUUIDFromParts([]byte("a"), []byte("bc"))
+	if ab == bc {
+		t.Errorf("NewHashUUIDFromParts: (\"ab\", \"c\") and (\"a\", \"bc\") both produced %v", ab)
+	}
+
+	_, err = GeneratorBase{}.NewHashUUIDFromParts()
+	compareError(t, "GeneratorBase", ErrMethodNotSupported{Method: MethodNewHashUUIDFromParts}, err)
+}
+
+func TestHashGenerator_Namespaces(t *testing.T) {
+	g, err := NewHashGenerator(5, Options{Namespace: NamespaceDNS, CanonicalizeNames: true})
+	if err != nil {
+		t.Fatalf("NewHashGenerator: unexpected error: %v", err)
+	}
+	hg := g.(HashGenerator)
+
+	org, err := hg.NewHashUUID([]byte("Example.org"))
+	compareError(t, "NewHashUUID", nil, err)
+
+	sub, err := NewHashGenerator(5, Options{Namespace: org})
+	if err != nil {
+		t.Fatalf("NewHashGenerator: unexpected error: %v", err)
+	}
+	project, _ := sub.NewHashUUID([]byte("project"))
+
+	sub, err = NewHashGenerator(5, Options{Namespace: project})
+	if err != nil {
+		t.Fatalf("NewHashGenerator: unexpected error: %v", err)
+	}
+	dataset, _ := sub.NewHashUUID([]byte("dataset"))
+
+	uuid, err := hg.NewHashUUIDInNamespace(org, []byte("project"))
+	compareError(t, "NewHashUUIDInNamespace", nil, err)
+	compare[UUID](t, "NewHashUUIDInNamespace", project, uuid)
+
+	uuid, err = hg.NewHashUUIDInNamespace(NamespaceDNS, []byte("WWW.EXAMPLE.COM"))
+	compareError(t, "NewHashUUIDInNamespace/canonical", nil, err)
+	compare[string](t, "NewHashUUIDInNamespace/canonical", "2ed6657d-e927-568b-95e1-2665a8aea6a2", uuid.String())
+
+	_, err = hg.NewHashUUIDInNamespace(Nil, []byte("x"))
+	compareError(t, "NewHashUUIDInNamespace/nil", ErrNamespaceNotValid{Version: 5, Namespace: Nil}, err)
+
+	uuid, err = hg.NewHashUUIDFromPath([]byte("example.org."), []byte("project"), []byte("dataset"))
+	compareError(t, "NewHashUUIDFromPath", nil, err)
+	compare[UUID](t, "NewHashUUIDFromPath", dataset, uuid)
+
+	uuid, err = hg.NewHashUUIDFromPath()
+	compareError(t, "NewHashUUIDFromPath/empty", nil, err)
+	compare[UUID](t, "NewHashUUIDFromPath/empty", NamespaceDNS, uuid)
+
+	_, err = hg.NewHashUUIDFromPath([]byte("a..b"), []byte("x"))
+	compareError(t, "NewHashUUIDFromPath/invalid", ErrNameNotValid{Kind: NameDNS, Name: "a..b", Message: "name contains an empty label"}, err)
+}
@@ -0,0 +1,105 @@
+package youyouayedee
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// MinHashKeySize is the minimum length in bytes of HashKey.Secret.
+const MinHashKeySize = 16
+
+// HashKey is a secret key for NewKeyedHashGenerator.
+type HashKey struct {
+	// ID identifies the key, so that UUIDs produced with an old key can
+	// still be recognized after the key has been rotated.  It is stored
+	// in the clear as the first byte of every UUID.
+	ID byte
+
+	// Secret is the secret key material.  It must be at least
+	// MinHashKeySize bytes long, and no more than 64 bytes long for
+	// KeyedBLAKE2b256.
+	Secret []byte
+}
+
+// NewKeyedHashGenerator constructs a new Generator that produces keyed
+// hash-based V8 UUIDs.
+//
+// These UUIDs are deterministic for holders of the secret key, like V3 and V5
+// UUIDs, but unlike them they cannot be reversed by hashing guesses of a
+// predictable name, such as an e-mail address or an account number.
+//
+// Options must specify a valid, non-nil UUID in the Namespace field.  The
+// namespace and the name are authenticated together, exactly as they are
+// hashed together by NewHashGenerator.  The first byte of each UUID is the
+// key ID, and the remaining bits, apart from the version and variant bits,
+// are taken from the start of the MAC.
+//
+// To rotate keys, construct a new Generator with a new key ID.  The
+// KeyedHashKeyID function reports which key produced a given UUID.
+//
+// The returned Generator also implements HashGenerator.
+//
+func NewKeyedHashGenerator(algo KeyedHashAlgorithm, key HashKey, o Options) (Generator, error) {
+	if !algo.IsValid() {
+		return nil, ErrKeyedHashAlgorithmNotSupported{Algorithm: algo}
+	}
+
+	if len(key.Secret) < MinHashKeySize {
+		return nil, ErrHashKeyNotValid{Algorithm: algo, KeyID: key.ID, Message: fmt.Sprintf("secret is %d bytes; should be at least %d bytes", len(key.Secret), MinHashKeySize)}
+	}
+
+	// Copy the secret, so that later changes by the caller have no effect.
+	secret := make([]byte, len(key.Secret))
+	copy(secret, key.Secret)
+
+	var factory func() hash.Hash
+	switch algo {
+	case KeyedHMACSHA256:
+		factory = func() hash.Hash {
+			return hmac.New(sha256.New, secret)
+		}
+
+	case KeyedBLAKE2b256:
+		if len(secret) > blake2b.Size {
+			return nil, ErrHashKeyNotValid{Algorithm: algo, KeyID: key.ID, Message: fmt.Sprintf("secret is %d bytes; should be at most %d bytes", len(secret), blake2b.Size)}
+		}
+		factory = func() hash.Hash {
+			// New256 only fails for keys longer than blake2b.Size.
+			h, err := blake2b.New256(secret)
+			if err != nil {
+				panic(err)
+			}
+			return h
+		}
+
+	default:
+		return nil, ErrKeyedHashAlgorithmNotSupported{Algorithm: algo}
+	}
+
+	ns := o.Namespace
+	if !ns.IsValid() {
+		return nil, ErrNamespaceNotValid{Version: 8, Namespace: ns}
+	}
+
+	h := factory()
+	g := &genHash{ns: ns, fn: factory, ver: 8, busy: 0, hasher: h, keyed: true, keyID: key.ID, canonicalize: o.CanonicalizeNames}
+	if o.CanonicalizeNames {
+		g.canon = CanonicalizerForNamespace(ns)
+	}
+	return g, nil
+}
+
+// KeyedHashKeyID returns the key ID of a UUID produced by a Generator from
+// NewKeyedHashGenerator.  It returns false if the UUID is not an RFC 4122
+// variant V8 UUID.
+//
+func KeyedHashKeyID(uuid UUID) (byte, bool) {
+	if uuid.Variant() != VariantRFC4122 || uuid.Version() != 8 {
+		return 0, false
+	}
+	return uuid[0], true
+}
@@ -0,0 +1,67 @@
+package youyouayedee
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewKeyedHashGenerator(t *testing.T) {
+	secret := make([]byte, 32)
+	for index := range secret {
+		secret[index] = byte(index)
+	}
+	key := HashKey{ID: 7, Secret: secret}
+
+	type testRow struct {
+		Name      string
+		Algorithm KeyedHashAlgorithm
+		Expect    string
+	}
+
+	testData := [...]testRow{
+		{"HMAC-SHA-256", KeyedHMACSHA256, "071bdaa5-1471-8c44-bfc2-e6b8a3dbc0c5"},
+		{"BLAKE2b-256", KeyedBLAKE2b256, "076a9cd7-5574-83a8-9a15-a63fc0a56820"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			g, err := NewKeyedHashGenerator(row.Algorithm, key, Options{Namespace: NamespaceDNS})
+			if err != nil {
+				t.Fatalf("NewKeyedHashGenerator: unexpected error: %v", err)
+			}
+
+			uuid, err := g.NewHashUUID([]byte("alice@example.com"))
+			compareError(t, "NewHashUUID", nil, err)
+			compare[string](t, "NewHashUUID", row.Expect, uuid.String())
+
+			keyID, ok := KeyedHashKeyID(uuid)
+			compare[bool](t, "KeyedHashKeyID", true, ok)
+			compare[byte](t, "KeyedHashKeyID", 7, keyID)
+
+			uuid, err = g.(HashGenerator).NewHashUUIDFromReader(bytes.NewReader([]byte("alice@example.com")))
+			compareError(t, "NewHashUUIDFromReader", nil, err)
+			compare[string](t, "NewHashUUIDFromReader", row.Expect, uuid.String())
+		})
+	}
+
+	rotated, err := NewKeyedHashGenerator(KeyedHMACSHA256, HashKey{ID: 8, Secret: bytes.Repeat([]byte{0x55}, 32)}, Options{Namespace: NamespaceDNS})
+	compareError(t, "NewKeyedHashGenerator/rotated", nil, err)
+	uuid, _ := rotated.NewHashUUID([]byte("alice@example.com"))
+	keyID, _ := KeyedHashKeyID(uuid)
+	compare[byte](t, "KeyedHashKeyID/rotated", 8, keyID)
+
+	_, err = NewKeyedHashGenerator(KeyedHMACSHA256, HashKey{ID: 1, Secret: []byte("short")}, Options{Namespace: NamespaceDNS})
+	compareError(t, "NewKeyedHashGenerator/short", ErrHashKeyNotValid{Algorithm: KeyedHMACSHA256, KeyID: 1, Message: "secret is 5 bytes; should be at least 16 bytes"}, err)
+
+	_, err = NewKeyedHashGenerator(KeyedBLAKE2b256, HashKey{ID: 2, Secret: make([]byte, 65)}, Options{Namespace: NamespaceDNS})
+	compareError(t, "NewKeyedHashGenerator/long", ErrHashKeyNotValid{Algorithm: KeyedBLAKE2b256, KeyID: 2, Message: "secret is 65 bytes; should be at most 64 bytes"}, err)
+
+	_, err = NewKeyedHashGenerator(KeyedHashAlgorithm(99), HashKey{ID: 3, Secret: []byte("short")}, Options{Namespace: NamespaceDNS})
+	compareError(t, "NewKeyedHashGenerator/algorithm", ErrKeyedHashAlgorithmNotSupported{Algorithm: KeyedHashAlgorithm(99)}, err)
+
+	_, err = NewKeyedHashGenerator(KeyedHMACSHA256, key, Options{})
+	compareError(t, "NewKeyedHashGenerator/namespace", ErrNamespaceNotValid{Version: 8, Namespace: Nil}, err)
+
+	_, ok := KeyedHashKeyID(uuidV4)
+	compare[bool](t, "KeyedHashKeyID/V4", false, ok)
+}
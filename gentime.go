@@ -3,7 +3,6 @@ package youyouayedee
 import (
 	"encoding/binary"
 	"math/rand"
-	"sync"
 	"time"
 
 	"golang.org/x/crypto/blake2b"
@@ -15,111 +14,76 @@ import (
 // Versions 1, 6, 7, and 8 are supported.
 //
 func NewTimeGenerator(version Version, o Options) (Generator, error) {
-	var err error
-
 	if version != 1 && version != 6 && version != 7 && version != 8 {
 		return nil, ErrVersionMismatch{Requested: version, Expected: []Version{1, 6, 7, 8}}
 	}
 
-	node := o.Node
-	if node.IsZero() {
-		node, err = GenerateNode(o)
-		if err != nil {
-			return nil, ErrOperationFailed{Operation: GenerateNodeOp, Err: err}
-		}
-	}
-
-	now := o.TimeSource
-	if now == nil {
-		now = time.Now
-	}
-
 	lsc := o.LeapSecondCalculator
 	if lsc == nil {
 		lsc = LeapSecondCalculatorDummy{}
 	}
 
-	cs := o.ClockStorage
-	if cs == nil {
-		cs = ClockStorageUnavailable{}
+	g := &genTime{lsc: lsc, ver: version}
+	if err := g.state.init(o); err != nil {
+		return nil, err
 	}
-
-	last, clock, err := cs.Load(node)
-	if err != nil {
-		if !isErrClockNotFound(err) {
-			return nil, ErrOperationFailed{Operation: ClockStorageLoadOp, Err: err}
-		}
-
-		last = now()
-		clock = rand.Uint32()
+	if !g.state.started {
+		g.state.last = g.state.now()
+		g.state.clock = rand.Uint32()
+		g.state.started = true
 	}
-
-	return &genTime{
-		node:  node,
-		now:   now,
-		lsc:   lsc,
-		cs:    cs,
-		ver:   version,
-		last:  last,
-		clock: clock,
-	}, nil
+	return g, nil
 }
 
 type genTime struct {
 	GeneratorBase
 
-	node  Node
-	now   func() time.Time
 	lsc   LeapSecondCalculator
-	cs    ClockStorage
 	ver   Version
-	mu    sync.Mutex
-	last  time.Time
-	clock uint32
+	state clockState
 }
 
 func (g *genTime) NewUUID() (UUID, error) {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
-	now := g.now()
-
-	if g.last.Before(now) {
-		g.last = now
-	} else {
-		now = g.last
-		g.clock++
-	}
-
-	err := g.cs.Store(g.node, g.last, g.clock)
+	var now time.Time
+	var clock uint32
+	err := g.state.advance(func(t time.Time) (time.Time, uint32, error) {
+		now, clock = t, g.state.clock
+		if !g.state.last.Before(now) {
+			now = g.state.last
+			clock++
+		}
+		return now, clock, nil
+	})
 	if err != nil {
-		return Nil, ErrOperationFailed{Operation: ClockStorageStoreOp, Err: err}
+		return Nil, err
 	}
 
+	node := g.state.node
+
 	var uuid UUID
 	var ticks uint64
 
 	if g.ver == 1 {
 		ticks = goTimeToGregorianTicks(g.lsc, now)
 		putV1Ticks(uuid[0:8], ticks)
-		putClock14(uuid[8:10], g.clock)
-		copy(uuid[10:16], g.node[0:6])
+		putClock14(uuid[8:10], clock)
+		copy(uuid[10:16], node[0:6])
 	} else if g.ver == 6 {
 		ticks = goTimeToGregorianTicks(g.lsc, now)
 		putV6Ticks(uuid[0:8], ticks)
-		putClock14(uuid[8:10], g.clock)
-		copy(uuid[10:16], g.node[0:6])
+		putClock14(uuid[8:10], clock)
+		copy(uuid[10:16], node[0:6])
 	} else {
 		ticks = goTimeToUnixTicks(now)
 
 		var hashInput [18]byte
 		binary.BigEndian.PutUint64(hashInput[0:8], ticks)
-		binary.BigEndian.PutUint32(hashInput[8:12], g.clock)
-		copy(hashInput[12:18], g.node[0:6])
+		binary.BigEndian.PutUint32(hashInput[8:12], clock)
+		copy(hashInput[12:18], node[0:6])
 		sum := blake2b.Sum256(hashInput[:])
 
 		putUint48(uuid[0:6], ticks)
-		putClock32(uuid[6:11], g.clock)
+		putClock32(uuid[6:11], clock)
 		copy(uuid[11:16], sum[0:5])
 	}
 
@@ -140,7 +104,8 @@ func goTimeToGregorianTicks(lsc LeapSecondCalculator, now time.Time) uint64 {
 		s--
 	}
 
-	s += int64(lsc.LeapSecondsSinceUnixEpoch(s, false))
+	s, ns64 := unixTimeToSITime(lsc, s, int64(ns))
+	ns = int(ns64)
 	s += secondsFromGregorianEpochToUnixEpoch
 
 	if s < 0 {
@@ -159,7 +124,7 @@ func gregorianTicksToGoTime(lsc LeapSecondCalculator, num uint64) time.Time {
 	s := int64(num / ticksPerSecond)
 
 	s -= secondsFromGregorianEpochToUnixEpoch
-	s -= int64(lsc.LeapSecondsSinceUnixEpoch(s, true))
+	s, ns = siTimeToUnixTime(lsc, s, ns)
 
 	if s < 0 && ns > 0 {
 		ns = nanosPerSecond - ns
@@ -201,3 +166,9 @@ func unixTicksToGoTime(num int64) time.Time {
 
 	return time.Unix(s, ns)
 }
+
+func ncsTicksToGoTime(num uint64) time.Time {
+	ns := int64(num%ncsTicksPerSecond) * nanosPerNCSTick
+	s := int64(num/ncsTicksPerSecond) + ncsEpochUnixSeconds
+	return time.Unix(s, ns)
+}
@@ -0,0 +1,27 @@
+package youyouayedee
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewTimeGenerator_NoClockState(t *testing.T) {
+	for _, version := range [...]Version{1, 6, 7} {
+		t.Run(fmt.Sprintf("V%d", version), func(t *testing.T) {
+			g, err := NewTimeGenerator(version, Options{})
+			compareError(t, "NewTimeGenerator", nil, err)
+
+			cs := &testClockStorage{}
+			g, err = NewTimeGenerator(version, Options{ClockStorage: cs, ForceRandomNode: true})
+			compareError(t, "NewTimeGenerator/empty", nil, err)
+			if g == nil {
+				return
+			}
+
+			uuid, err := g.NewUUID()
+			compareError(t, "NewUUID", nil, err)
+			compare[Version](t, "Version", version, uuid.Version())
+			compare[bool](t, "ClockStorage", true, cs.saved)
+		})
+	}
+}
@@ -2,6 +2,12 @@ module github.com/chronos-tachyon/youyouayedee
 
 go 1.18
 
-require golang.org/x/crypto v0.0.0-20220622213112-05595931fe9d
+require (
+	golang.org/x/crypto v0.14.0
+	golang.org/x/net v0.17.0
+)
 
-require golang.org/x/sys v0.0.0-20210615035016-665e8c7367d1 // indirect
+require (
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+)
@@ -0,0 +1,192 @@
+package youyouayedee
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// LoadLeapSecondsList reads and validates a file in the IETF/IANA
+// "leap-seconds.list" format, such as the copy which many Linux distributions
+// install as "/usr/share/zoneinfo/leap-seconds.list".
+//
+// See ParseLeapSecondsList for details.
+//
+func LoadLeapSecondsList(fileName string) (*LeapSecondCalculatorTable, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, ErrOperationFailed{Operation: OpenLeapSecondFileOp, Err: err}
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return parseLeapSecondsList(fileName, f)
+}
+
+// ParseLeapSecondsList parses and validates data in the IETF/IANA
+// "leap-seconds.list" format.
+//
+// The data must contain a "#$" last update line, a "#@" expiration line, and
+// a "#h" SHA-1 hash line, and the hash must match the data.  Timestamps must
+// be strictly increasing, and each leap second must change TAI-UTC by exactly
+// one second.  If any of these checks fail, ErrLeapSecondDataNotValid is
+// returned.
+//
+// Note that an expired file is not considered invalid.  Use the IsExpired
+// method of the result to check for that case.
+//
+func ParseLeapSecondsList(r io.Reader) (*LeapSecondCalculatorTable, error) {
+	return parseLeapSecondsList("", r)
+}
+
+func parseLeapSecondsList(name string, r io.Reader) (*LeapSecondCalculatorTable, error) {
+	var updatedText, expiresText []byte
+	var updated, expires uint64
+	var hashLine uint
+	var hashWords [5]uint32
+	var hasUpdated, hasExpires, hasHash bool
+	var lastDTAI int64
+
+	table := make([]leapSecond, 1, 32)
+	table[0] = leapSecond{Time: -(1 << 63), Total: 0}
+
+	hashData := make([]byte, 0, 1024)
+	fail := func(lineNum uint, format string, args ...interface{}) error {
+		return ErrLeapSecondDataNotValid{Name: name, Line: lineNum, Message: fmt.Sprintf(format, args...)}
+	}
+
+	sc := bufio.NewScanner(r)
+	lineNum := uint(0)
+	for sc.Scan() {
+		lineNum++
+		line := bytes.TrimRight(sc.Bytes(), "\r")
+
+		if len(line) >= 2 && line[0] == '#' {
+			switch line[1] {
+			case '$', '@':
+				fields := bytes.Fields(line[2:])
+				if len(fields) < 1 {
+					return nil, fail(lineNum, "missing NTP timestamp after %q", line[0:2])
+				}
+				value, err := strconv.ParseUint(string(fields[0]), 10, 64)
+				if err != nil {
+					return nil, fail(lineNum, "invalid NTP timestamp %q", fields[0])
+				}
+				if line[1] == '$' {
+					updatedText = append([]byte(nil), fields[0]...)
+					updated = value
+					hasUpdated = true
+				} else {
+					expiresText = append([]byte(nil), fields[0]...)
+					expires = value
+					hasExpires = true
+				}
+				continue
+
+			case 'h':
+				fields := bytes.Fields(line[2:])
+				if len(fields) != 5 {
+					return nil, fail(lineNum, "expected 5 hash words, found %d", len(fields))
+				}
+				for wi, word := range fields {
+					value, err := strconv.ParseUint(string(word), 16, 32)
+					if err != nil {
+						return nil, fail(lineNum, "invalid hash word %q", word)
+					}
+					hashWords[wi] = uint32(value)
+				}
+				hashLine = lineNum
+				hasHash = true
+				continue
+			}
+		}
+
+		if ci := bytes.IndexByte(line, '#'); ci >= 0 {
+			line = line[:ci]
+		}
+
+		fields := bytes.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fail(lineNum, "expected NTP timestamp and TAI-UTC offset")
+		}
+
+		ntp, err := strconv.ParseUint(string(fields[0]), 10, 64)
+		if err != nil {
+			return nil, fail(lineNum, "invalid NTP timestamp %q", fields[0])
+		}
+
+		dtai, err := strconv.ParseInt(string(fields[1]), 10, 32)
+		if err != nil {
+			return nil, fail(lineNum, "invalid TAI-UTC offset %q", fields[1])
+		}
+
+		t := int64(ntp) - secondsFromNTPEpochToUnixEpoch
+		if tableLen := len(table); tableLen > 1 {
+			if t <= table[tableLen-1].Time {
+				return nil, fail(lineNum, "timestamps are not strictly increasing")
+			}
+			if delta := dtai - lastDTAI; delta != 1 && delta != -1 {
+				return nil, fail(lineNum, "TAI-UTC offset changed by %d seconds; expected 1 or -1", delta)
+			}
+		}
+
+		table = append(table, leapSecond{Time: t, Total: int(dtai - taiMinusUTCAtZeroLeapSeconds)})
+		lastDTAI = dtai
+
+		hashData = append(hashData, fields[0]...)
+		hashData = append(hashData, fields[1]...)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, ErrOperationFailed{Operation: ReadLeapSecondDataOp, Err: err}
+	}
+
+	switch {
+	case len(table) <= 1:
+		return nil, fail(0, "no leap seconds found")
+	case !hasUpdated:
+		return nil, fail(0, "missing \"#$\" last update line")
+	case !hasExpires:
+		return nil, fail(0, "missing \"#@\" expiration line")
+	case !hasHash:
+		return nil, fail(0, "missing \"#h\" hash line")
+	}
+
+	// The hash covers the last update timestamp, the expiration timestamp,
+	// and the first two fields of each data line, in that order, with all
+	// whitespace and comments removed.
+	h := sha1.New()
+	_, _ = h.Write(updatedText)
+	_, _ = h.Write(expiresText)
+	_, _ = h.Write(hashData)
+	sum := h.Sum(nil)
+
+	var expectSum [sha1.Size]byte
+	for wi := uint(0); wi < 5; wi++ {
+		binary.BigEndian.PutUint32(expectSum[wi*4:], hashWords[wi])
+	}
+	if !bytes.Equal(sum, expectSum[:]) {
+		return nil, fail(hashLine, "SHA-1 hash mismatch; data is corrupt or truncated")
+	}
+
+	return &LeapSecondCalculatorTable{
+		table:   table,
+		updated: ntpSecondsToGoTime(updated),
+		expires: ntpSecondsToGoTime(expires),
+	}, nil
+}
+
+func ntpSecondsToGoTime(ntp uint64) time.Time {
+	return time.Unix(int64(ntp)-secondsFromNTPEpochToUnixEpoch, 0).UTC()
+}
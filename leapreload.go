@@ -0,0 +1,192 @@
+package youyouayedee
+
+import (
+	"io"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LeapSecondLoader is a callback which loads a fresh table of leap seconds,
+// e.g. by calling LoadLeapSecondsList or LoadTZifLeapSeconds.
+type LeapSecondLoader func() (*LeapSecondCalculatorTable, error)
+
+// LeapSecondCalculatorReloader is a LeapSecondCalculator which wraps a
+// LeapSecondCalculatorTable and periodically replaces it with a freshly
+// loaded copy, so that long-running programs pick up newly announced leap
+// seconds without restarting.
+//
+// Lookups never block: the current table is swapped atomically, so reloads
+// do not hold up UUID generation.  Reloads are serialized, so the most
+// recently started reload always determines the table that remains in use.
+// If a reload fails, the previous table remains in use and the error is
+// reported by LastError.
+//
+type LeapSecondCalculatorReloader struct {
+	load    LeapSecondLoader
+	current atomic.Value
+
+	reloadMu sync.Mutex
+
+	mu         sync.Mutex
+	lastErr    error
+	lastReload time.Time
+	closed     bool
+	stopCh     chan struct{}
+	doneCh     chan struct{}
+}
+
+// NewLeapSecondCalculatorReloader constructs a LeapSecondCalculatorReloader
+// which calls the given loader once immediately and then again every
+// interval until Close is called.
+//
+// If the initial load fails, or if the loader returns a nil table, the error
+// is returned and no background goroutine is started.  If interval is zero or
+// negative, no background goroutine is started either, but the Reload method
+// can still be called manually.
+//
+func NewLeapSecondCalculatorReloader(load LeapSecondLoader, interval time.Duration) (*LeapSecondCalculatorReloader, error) {
+	lsc, err := callLeapSecondLoader(load)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &LeapSecondCalculatorReloader{
+		load:       load,
+		lastReload: time.Now(),
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+	r.current.Store(lsc)
+
+	if interval > 0 {
+		go r.loop(interval)
+	} else {
+		close(r.doneCh)
+	}
+	return r, nil
+}
+
+// WatchLeapSecondsList is a convenience wrapper which calls
+// NewLeapSecondCalculatorReloader with a loader that reads the given
+// "leap-seconds.list" file using LoadLeapSecondsList.
+func WatchLeapSecondsList(fileName string, interval time.Duration) (*LeapSecondCalculatorReloader, error) {
+	return NewLeapSecondCalculatorReloader(func() (*LeapSecondCalculatorTable, error) {
+		return LoadLeapSecondsList(fileName)
+	}, interval)
+}
+
+// WatchTZifLeapSeconds is a convenience wrapper which calls
+// NewLeapSecondCalculatorReloader with a loader that reads the given TZif
+// file using LoadTZifLeapSeconds.
+func WatchTZifLeapSeconds(fileName string, interval time.Duration) (*LeapSecondCalculatorReloader, error) {
+	return NewLeapSecondCalculatorReloader(func() (*LeapSecondCalculatorTable, error) {
+		return LoadTZifLeapSeconds(fileName)
+	}, interval)
+}
+
+func (r *LeapSecondCalculatorReloader) LeapSecondsSinceUnixEpoch(seconds int64, includesLeapSeconds bool) int {
+	return r.Current().LeapSecondsSinceUnixEpoch(seconds, includesLeapSeconds)
+}
+
+// Current returns the table which is currently in use.
+func (r *LeapSecondCalculatorReloader) Current() *LeapSecondCalculatorTable {
+	return r.current.Load().(*LeapSecondCalculatorTable)
+}
+
+// Expires returns the expiration time of the table which is currently in
+// use, or the zero time if unknown.
+func (r *LeapSecondCalculatorReloader) Expires() time.Time {
+	return r.Current().Expires()
+}
+
+// IsExpired returns true iff the table which is currently in use has a known
+// expiration date and the given time is at or after it.
+func (r *LeapSecondCalculatorReloader) IsExpired(now time.Time) bool {
+	return r.Current().IsExpired(now)
+}
+
+// LastError returns the error from the most recent reload attempt, or nil if
+// it succeeded.
+func (r *LeapSecondCalculatorReloader) LastError() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastErr
+}
+
+// LastReload returns the time of the most recent successful load.
+func (r *LeapSecondCalculatorReloader) LastReload() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastReload
+}
+
+// Reload immediately calls the loader and, if it succeeds, swaps in the new
+// table.  The result is also recorded for LastError.
+//
+// If another reload is already in progress, Reload waits for it to finish
+// first.  A loader which returns a nil table without an error is treated as
+// a failure, and the previous table remains in use.
+//
+func (r *LeapSecondCalculatorReloader) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	lsc, err := callLeapSecondLoader(r.load)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastErr = err
+	if err == nil {
+		r.current.Store(lsc)
+		r.lastReload = time.Now()
+	}
+	return err
+}
+
+// Close stops the background goroutine and waits for it to exit.  The most
+// recently loaded table remains available for lookups.
+func (r *LeapSecondCalculatorReloader) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return fs.ErrClosed
+	}
+	r.closed = true
+	close(r.stopCh)
+	r.mu.Unlock()
+
+	<-r.doneCh
+	return nil
+}
+
+func callLeapSecondLoader(load LeapSecondLoader) (*LeapSecondCalculatorTable, error) {
+	lsc, err := load()
+	if err == nil && lsc == nil {
+		err = ErrLeapSecondDataNotValid{Message: "loader returned no table"}
+	}
+	return lsc, err
+}
+
+func (r *LeapSecondCalculatorReloader) loop(interval time.Duration) {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			_ = r.Reload()
+		}
+	}
+}
+
+var (
+	_ LeapSecondCalculator = (*LeapSecondCalculatorReloader)(nil)
+	_ io.Closer            = (*LeapSecondCalculatorReloader)(nil)
+)
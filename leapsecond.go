@@ -1,5 +1,10 @@
 package youyouayedee
 
+import (
+	"sort"
+	"time"
+)
+
 // LeapSecondCalculator is an interface for calculating the number of leap
 // seconds by which Unix time differs from the number of SI seconds since
 // 1970-01-01T00:00:00Z UTC.
@@ -39,6 +44,51 @@ var _ LeapSecondCalculator = LeapSecondCalculatorDummy{}
 type LeapSecondCalculatorFixed struct{}
 
 func (LeapSecondCalculatorFixed) LeapSecondsSinceUnixEpoch(seconds int64, includesLeapSeconds bool) int {
+	return leapSecondsFromTable(fixedLeapSecondTable[:], seconds, includesLeapSeconds)
+}
+
+var _ LeapSecondCalculator = LeapSecondCalculatorFixed{}
+
+// LeapSecondCalculatorTable calculates the number of leap seconds that have
+// elapsed before a given time using a table of leap seconds that was loaded
+// at runtime, e.g. by LoadLeapSecondsList.
+//
+// Unlike LeapSecondCalculatorFixed, the table carries an expiration date,
+// after which the absence of further leap seconds is no longer guaranteed.
+// Times after the expiration date are still computed using the last known
+// leap second, but callers should arrange to load fresh data.
+//
+type LeapSecondCalculatorTable struct {
+	table   []leapSecond
+	updated time.Time
+	expires time.Time
+}
+
+// Updated returns the time at which the leap second data was last updated by
+// its publisher, or the zero time if unknown.
+func (lsc *LeapSecondCalculatorTable) Updated() time.Time {
+	return lsc.updated
+}
+
+// Expires returns the time at which the leap second data expires, or the zero
+// time if unknown.
+func (lsc *LeapSecondCalculatorTable) Expires() time.Time {
+	return lsc.expires
+}
+
+// IsExpired returns true iff the leap second data has a known expiration date
+// and the given time is at or after it.
+func (lsc *LeapSecondCalculatorTable) IsExpired(now time.Time) bool {
+	return !lsc.expires.IsZero() && !now.Before(lsc.expires)
+}
+
+func (lsc *LeapSecondCalculatorTable) LeapSecondsSinceUnixEpoch(seconds int64, includesLeapSeconds bool) int {
+	return leapSecondsFromTable(lsc.table, seconds, includesLeapSeconds)
+}
+
+var _ LeapSecondCalculator = (*LeapSecondCalculatorTable)(nil)
+
+func leapSecondsFromTable(table []leapSecond, seconds int64, includesLeapSeconds bool) int {
 
 	// NB: there are corner cases at each leap second boundary.
 	//
@@ -46,9 +96,9 @@ func (LeapSecondCalculatorFixed) LeapSecondsSinceUnixEpoch(seconds int64, includ
 	//
 	//     UTC Time              Unix seconds    SI seconds  "Total" field
 	//     --------------------  ------------  ------------  -------------
-	//     2016-12-31T23:59:59Z    1483228799    1483228825             26
-	//     2016-12-31T23:59:60Z             -    1483228826             26
-	//     2017-01-01T00:00:00Z    1483228800    1483228827             27
+	//     2016-12-31T23:59:59Z    1483228799    1483228826             27
+	//     2016-12-31T23:59:60Z             -    1483228827             28
+	//     2017-01-01T00:00:00Z    1483228800    1483228828             28
 	//
 	//     This affects (includesLeapSeconds == true).
 	//
@@ -56,36 +106,49 @@ func (LeapSecondCalculatorFixed) LeapSecondsSinceUnixEpoch(seconds int64, includ
 	//
 	//     UTC Time              Unix seconds    SI seconds  "Total" field
 	//     --------------------  ------------  ------------  -------------
-	//     2016-12-31T23:59:58Z    1483228798    1483228825             27
-	//                        -    1483228799             -             27
-	//     2017-01-01T00:00:00Z    1483228800    1483228826             26
+	//     2016-12-31T23:59:58Z    1483228798    1483228826             28
+	//                        -    1483228799             -             28
+	//     2017-01-01T00:00:00Z    1483228800    1483228827             27
 	//
 	//     This affects (includesLeapSeconds == false).
 
-	tableLen := uint(len(fixedLeapSecondTable))
-	ti := uint(1)
-	total := int(0)
-	for ti < tableLen {
-		row := fixedLeapSecondTable[ti]
-		t := row.Time
-		if includesLeapSeconds {
-			minTotal := row.Total
-			if minTotal > total {
-				minTotal = total
-			}
-			t += int64(minTotal)
-		}
-		total = row.Total
-		if seconds < t {
-			break
-		}
-		ti++
+	tableLen := uint(len(table))
+	if tableLen < 2 {
+		return 0
 	}
-	return total
+
+	// Fast path: nearly all lookups are for the current time, which is
+	// almost always after the most recent leap second.
+	last := tableLen - 1
+	if seconds >= leapSecondBoundary(table, last, includesLeapSeconds) {
+		return table[last].Total
+	}
+
+	// Find the first row whose boundary is after the given time.  Row 0
+	// is a sentinel that precedes all times, so the answer is at least 1.
+	ti := uint(1) + uint(sort.Search(int(last), func(i int) bool {
+		return seconds < leapSecondBoundary(table, uint(i)+1, includesLeapSeconds)
+	}))
+	return table[ti-1].Total
 }
 
-var _ LeapSecondCalculator = LeapSecondCalculatorFixed{}
+func leapSecondBoundary(table []leapSecond, ti uint, includesLeapSeconds bool) int64 {
+	row := table[ti]
+	t := row.Time
+	if includesLeapSeconds {
+		minTotal := row.Total
+		if prevTotal := table[ti-1].Total; minTotal > prevTotal {
+			minTotal = prevTotal
+		}
+		t += int64(minTotal)
+	}
+	return t
+}
 
+// leapSecond is one row of a leap second table.  Total is TAI-UTC minus 9
+// seconds, so that the initial 10 second offset of 1 Jan 1972 counts as the
+// first leap second.
+//
 type leapSecond struct {
 	Time  int64
 	Total int
@@ -0,0 +1,530 @@
+package youyouayedee
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testLeapSecondsList = `#
+#	Abridged copy of the IERS "leap-seconds.list" file.
+#
+#$	 3960835200
+#@	3991593600
+#
+2272060800	10	# 1 Jan 1972
+2287785600	11	# 1 Jul 1972
+2303683200	12	# 1 Jan 1973
+2335219200	13	# 1 Jan 1974
+2366755200	14	# 1 Jan 1975
+2398291200	15	# 1 Jan 1976
+2429913600	16	# 1 Jan 1977
+2461449600	17	# 1 Jan 1978
+2492985600	18	# 1 Jan 1979
+2524521600	19	# 1 Jan 1980
+2571782400	20	# 1 Jul 1981
+2603318400	21	# 1 Jul 1982
+2634854400	22	# 1 Jul 1983
+2698012800	23	# 1 Jul 1985
+2776982400	24	# 1 Jan 1988
+2840140800	25	# 1 Jan 1990
+2871676800	26	# 1 Jan 1991
+2918937600	27	# 1 Jul 1992
+2950473600	28	# 1 Jul 1993
+2982009600	29	# 1 Jul 1994
+3029443200	30	# 1 Jan 1996
+3076704000	31	# 1 Jul 1997
+3124137600	32	# 1 Jan 1999
+3345062400	33	# 1 Jan 2006
+3439756800	34	# 1 Jan 2009
+3550089600	35	# 1 Jul 2012
+3644697600	36	# 1 Jul 2015
+3692217600	37	# 1 Jan 2017
+#
+#h	49db2447 571e5e1b 2f002a53 9c8da8e4 39b8e49e
+`
+
+func checkLeapSecondTotals(t *testing.T, lsc LeapSecondCalculator) {
+	t.Helper()
+
+	type testRow struct {
+		Name     string
+		Time     time.Time
+		Includes bool
+		Expect   int
+	}
+
+	testData := [...]testRow{
+		{Name: "1971", Time: time.Date(1971, time.December, 31, 23, 59, 59, 0, time.UTC), Expect: 0},
+		{Name: "1972", Time: time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), Expect: 1},
+		{Name: "before 1972 leap", Time: time.Date(1972, time.June, 30, 23, 59, 59, 0, time.UTC), Expect: 1},
+		{Name: "after 1972 leap", Time: time.Date(1972, time.July, 1, 0, 0, 0, 0, time.UTC), Expect: 2},
+		{Name: "1999", Time: time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC), Expect: 23},
+		{Name: "before 2012 leap", Time: time.Date(2012, time.June, 30, 23, 59, 59, 0, time.UTC), Expect: 25},
+		{Name: "after 2012 leap", Time: time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC), Expect: 26},
+		{Name: "before 2017 leap", Time: time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC), Expect: 27},
+		{Name: "after 2017 leap", Time: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), Expect: 28},
+		{Name: "2025", Time: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), Expect: 28},
+		{Name: "before 2017 leap/SI", Time: time.Unix(1483228826, 0), Includes: true, Expect: 27},
+		{Name: "during 2017 leap/SI", Time: time.Unix(1483228827, 0), Includes: true, Expect: 28},
+		{Name: "after 2017 leap/SI", Time: time.Unix(1483228828, 0), Includes: true, Expect: 28},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Name)
+		actual := lsc.LeapSecondsSinceUnixEpoch(row.Time.Unix(), row.Includes)
+		compare[int](t, testName, row.Expect, actual)
+	}
+}
+
+func TestLeapSecondCalculatorFixed(t *testing.T) {
+	checkLeapSecondTotals(t, LeapSecondCalculatorFixed{})
+}
+
+func TestDecodeV1_LeapSeconds(t *testing.T) {
+	// 2016-12-31T23:59:59Z is 1 483 228 799 Unix seconds, plus 27 leap
+	// seconds, plus 12 219 292 800 seconds from the UUID epoch.
+	var uuid UUID
+	putV1Ticks(uuid[0:8], 13702521626*ticksPerSecond)
+	uuid[6] = (uuid[6] & 0x0f) | 0x10
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	expect := time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC)
+	decoded := uuid.Decode(LeapSecondCalculatorFixed{})
+	compare[Version](t, "Version", 1, decoded.Version)
+	compare[time.Time](t, "Time", expect, decoded.Time.UTC())
+	compare[uint64](t, "goTimeToGregorianTicks", 13702521626*ticksPerSecond, goTimeToGregorianTicks(LeapSecondCalculatorFixed{}, expect))
+}
+
+func TestParseLeapSecondsList(t *testing.T) {
+	lsc, err := ParseLeapSecondsList(strings.NewReader(testLeapSecondsList))
+	if err != nil {
+		t.Fatalf("ParseLeapSecondsList: unexpected error: %v", err)
+	}
+
+	compare[time.Time](t, "Updated", time.Date(2025, time.July, 7, 0, 0, 0, 0, time.UTC), lsc.Updated())
+	compare[time.Time](t, "Expires", time.Date(2026, time.June, 28, 0, 0, 0, 0, time.UTC), lsc.Expires())
+	compare[bool](t, "IsExpired/before", false, lsc.IsExpired(time.Date(2026, time.June, 27, 0, 0, 0, 0, time.UTC)))
+	compare[bool](t, "IsExpired/after", true, lsc.IsExpired(time.Date(2026, time.June, 28, 0, 0, 0, 0, time.UTC)))
+
+	checkLeapSecondTotals(t, lsc)
+}
+
+func TestParseLeapSecondsList_Invalid(t *testing.T) {
+	type testRow struct {
+		Name  string
+		Input string
+		Err   error
+	}
+
+	testData := [...]testRow{
+		{
+			Name:  "corrupt",
+			Input: strings.Replace(testLeapSecondsList, "3692217600\t37", "3692217600\t38", 1),
+			Err:   ErrLeapSecondDataNotValid{Line: 34, Message: "TAI-UTC offset changed by 2 seconds; expected 1 or -1"},
+		},
+		{
+			Name:  "hash-mismatch",
+			Input: strings.Replace(testLeapSecondsList, "#@\t3991593600", "#@\t3991593601", 1),
+			Err:   ErrLeapSecondDataNotValid{Line: 36, Message: "SHA-1 hash mismatch; data is corrupt or truncated"},
+		},
+		{
+			Name:  "missing-hash",
+			Input: strings.Replace(testLeapSecondsList, "#h", "# ", 1),
+			Err:   ErrLeapSecondDataNotValid{Message: "missing \"#h\" hash line"},
+		},
+		{
+			Name:  "missing-expiration",
+			Input: strings.Replace(testLeapSecondsList, "#@", "# ", 1),
+			Err:   ErrLeapSecondDataNotValid{Message: "missing \"#@\" expiration line"},
+		},
+		{
+			Name:  "out-of-order",
+			Input: strings.Replace(testLeapSecondsList, "2287785600\t11", "2272060800\t11", 1),
+			Err:   ErrLeapSecondDataNotValid{Line: 8, Message: "timestamps are not strictly increasing"},
+		},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Name)
+		t.Run(testName, func(t *testing.T) {
+			_, err := ParseLeapSecondsList(strings.NewReader(row.Input))
+			compareError(t, "ParseLeapSecondsList", row.Err, err)
+		})
+	}
+}
+
+func TestLoadLeapSecondsList_NotFound(t *testing.T) {
+	_, err := LoadLeapSecondsList("/nonexistent/leap-seconds.list")
+	var opErr ErrOperationFailed
+	if !errors.As(err, &opErr) {
+		t.Fatalf("LoadLeapSecondsList: unexpected error: %v", err)
+	}
+	compare[Operation](t, "Operation", OpenLeapSecondFileOp, opErr.Operation)
+	compare[bool](t, "errors.Is", true, errors.Is(err, fs.ErrNotExist))
+}
+
+func makeTestTZif(leaps [][2]int64) []byte {
+	var buf bytes.Buffer
+	writeHeader := func(leapcnt int) {
+		var header [44]byte
+		copy(header[0:5], "TZif4")
+		binary.BigEndian.PutUint32(header[28:32], uint32(leapcnt))
+		binary.BigEndian.PutUint32(header[36:40], 1)
+		binary.BigEndian.PutUint32(header[40:44], 4)
+		buf.Write(header[:])
+	}
+
+	// Version 1 data block: one ttinfo, "UTC\x00", no leap records.
+	writeHeader(0)
+	buf.Write([]byte{0, 0, 0, 0, 0, 0})
+	buf.WriteString("UTC\x00")
+
+	// Version 2+ data block.
+	writeHeader(len(leaps))
+	buf.Write([]byte{0, 0, 0, 0, 0, 0})
+	buf.WriteString("UTC\x00")
+	for _, leap := range leaps {
+		var record [12]byte
+		binary.BigEndian.PutUint64(record[0:8], uint64(leap[0]))
+		binary.BigEndian.PutUint32(record[8:12], uint32(leap[1]))
+		buf.Write(record[:])
+	}
+	buf.WriteString("\n\n")
+	return buf.Bytes()
+}
+
+func TestParseTZifLeapSeconds(t *testing.T) {
+	// Convert fixedLeapSecondTable into TZif leap records, skipping the
+	// synthetic 1972-01-01 row, then append an expiration record.
+	leaps := make([][2]int64, 0, len(fixedLeapSecondTable))
+	for index, row := range fixedLeapSecondTable {
+		if index < 2 {
+			continue
+		}
+		corr := int64(row.Total - 1)
+		leaps = append(leaps, [2]int64{row.Time + corr - 1, corr})
+	}
+	expires := time.Date(2026, time.June, 28, 0, 0, 0, 0, time.UTC)
+	leaps = append(leaps, [2]int64{expires.Unix() + 27, 27})
+
+	lsc, err := ParseTZifLeapSeconds(bytes.NewReader(makeTestTZif(leaps)))
+	if err != nil {
+		t.Fatalf("ParseTZifLeapSeconds: unexpected error: %v", err)
+	}
+
+	compare[time.Time](t, "Expires", expires, lsc.Expires())
+	checkLeapSecondTotals(t, lsc)
+
+	_, err = ParseTZifLeapSeconds(bytes.NewReader(makeTestTZif(nil)))
+	compareError(t, "ParseTZifLeapSeconds", ErrLeapSecondDataNotValid{Message: "no leap second records found; is this a \"right/\" zone file?"}, err)
+
+	_, err = ParseTZifLeapSeconds(strings.NewReader("TZzz"))
+	compareError(t, "ParseTZifLeapSeconds", ErrLeapSecondDataNotValid{Message: "not a TZif file"}, err)
+}
+
+func TestLoadSystemLeapSeconds(t *testing.T) {
+	leaps := [][2]int64{{78796800, 1}, {94694401, 2}}
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "right"), 0o755); err != nil {
+		t.Fatalf("Mkdir: unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "right", "UTC"), makeTestTZif(leaps), 0o644); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+	t.Setenv("ZONEINFO", dir)
+
+	lsc, err := LoadSystemLeapSeconds()
+	if err != nil {
+		t.Fatalf("LoadSystemLeapSeconds: unexpected error: %v", err)
+	}
+	compare[int](t, "LeapSecondsSinceUnixEpoch", 3, lsc.LeapSecondsSinceUnixEpoch(100000000, false))
+
+	_, err = LoadTZifLeapSeconds(filepath.Join(dir, "right", "missing"))
+	var opErr ErrOperationFailed
+	if !errors.As(err, &opErr) {
+		t.Fatalf("LoadTZifLeapSeconds: unexpected error: %v", err)
+	}
+	compare[Operation](t, "Operation", OpenLeapSecondFileOp, opErr.Operation)
+	compare[bool](t, "errors.Is", true, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestLeapSecondCalculatorReloader(t *testing.T) {
+	errTest := errors.New("test error")
+	tableA := &LeapSecondCalculatorTable{table: fixedLeapSecondTable[:2], expires: time.Unix(100, 0)}
+	tableB := &LeapSecondCalculatorTable{table: fixedLeapSecondTable[:], expires: time.Unix(200, 0)}
+
+	var mu sync.Mutex
+	results := []*LeapSecondCalculatorTable{tableA, nil, tableB}
+	calls := 0
+	load := func() (*LeapSecondCalculatorTable, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		index := calls
+		if index >= len(results) {
+			index = len(results) - 1
+		}
+		calls++
+		if results[index] == nil {
+			return nil, errTest
+		}
+		return results[index], nil
+	}
+
+	r, err := NewLeapSecondCalculatorReloader(load, 0)
+	if err != nil {
+		t.Fatalf("NewLeapSecondCalculatorReloader: unexpected error: %v", err)
+	}
+	compare[*LeapSecondCalculatorTable](t, "Current", tableA, r.Current())
+	compare[time.Time](t, "Expires", time.Unix(100, 0), r.Expires())
+	compare[int](t, "LeapSecondsSinceUnixEpoch", 1, r.LeapSecondsSinceUnixEpoch(1500000000, false))
+
+	compareError(t, "Reload", errTest, r.Reload())
+	compareError(t, "LastError", errTest, r.LastError())
+	compare[*LeapSecondCalculatorTable](t, "Current", tableA, r.Current())
+
+	compareError(t, "Reload", nil, r.Reload())
+	compareError(t, "LastError", nil, r.LastError())
+	compare[*LeapSecondCalculatorTable](t, "Current", tableB, r.Current())
+	compare[time.Time](t, "Expires", time.Unix(200, 0), r.Expires())
+	compare[int](t, "LeapSecondsSinceUnixEpoch", 28, r.LeapSecondsSinceUnixEpoch(1500000000, false))
+
+	compareError(t, "Close", nil, r.Close())
+	compareError(t, "Close", fs.ErrClosed, r.Close())
+
+	mu.Lock()
+	calls = 0
+	mu.Unlock()
+
+	r, err = NewLeapSecondCalculatorReloader(load, time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewLeapSecondCalculatorReloader: unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Current() != tableB && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	compareError(t, "Close", nil, r.Close())
+	compare[*LeapSecondCalculatorTable](t, "Current", tableB, r.Current())
+}
+
+func TestLeapSecondCalculatorFixed_Transition(t *testing.T) {
+	type testRow struct {
+		Name   string
+		Leap   time.Time
+		Before int
+		At     int
+	}
+
+	testData := [...]testRow{
+		{"1972-01-01", time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), 0, 1},
+		{"2012-07-01", time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC), 25, 26},
+		{"2017-01-01", time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 27, 28},
+	}
+
+	var lsc LeapSecondCalculatorFixed
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			s := row.Leap.Unix()
+			compare[int](t, "before", row.Before, lsc.LeapSecondsSinceUnixEpoch(s-1, false))
+			compare[int](t, "at", row.At, lsc.LeapSecondsSinceUnixEpoch(s, false))
+		})
+	}
+}
+
+func TestLeapSecondCalculatorReloader_NilTable(t *testing.T) {
+	tableA := &LeapSecondCalculatorTable{table: fixedLeapSecondTable[:]}
+	errNoTable := ErrLeapSecondDataNotValid{Message: "loader returned no table"}
+
+	_, err := NewLeapSecondCalculatorReloader(func() (*LeapSecondCalculatorTable, error) {
+		return nil, nil
+	}, 0)
+	compareError(t, "NewLeapSecondCalculatorReloader", errNoTable, err)
+
+	next := tableA
+	r, err := NewLeapSecondCalculatorReloader(func() (*LeapSecondCalculatorTable, error) {
+		return next, nil
+	}, 0)
+	if err != nil {
+		t.Fatalf("NewLeapSecondCalculatorReloader: unexpected error: %v", err)
+	}
+
+	next = nil
+	compareError(t, "Reload", errNoTable, r.Reload())
+	compare[*LeapSecondCalculatorTable](t, "Current", tableA, r.Current())
+	compare[int](t, "LeapSecondsSinceUnixEpoch", 28, r.LeapSecondsSinceUnixEpoch(1500000000, false))
+	compareError(t, "Close", nil, r.Close())
+}
+
+func TestLeapSecondCalculatorReloader_Overlap(t *testing.T) {
+	tableA := &LeapSecondCalculatorTable{table: fixedLeapSecondTable[:2]}
+	tableOld := &LeapSecondCalculatorTable{table: fixedLeapSecondTable[:3]}
+	tableNew := &LeapSecondCalculatorTable{table: fixedLeapSecondTable[:]}
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	var mu sync.Mutex
+	calls := 0
+	load := func() (*LeapSecondCalculatorTable, error) {
+		mu.Lock()
+		calls++
+		index := calls
+		mu.Unlock()
+
+		switch index {
+		case 1:
+			return tableA, nil
+		case 2:
+			close(entered)
+			<-release
+			return tableOld, nil
+		default:
+			return tableNew, nil
+		}
+	}
+
+	r, err := NewLeapSecondCalculatorReloader(load, 0)
+	if err != nil {
+		t.Fatalf("NewLeapSecondCalculatorReloader: unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = r.Reload()
+	}()
+	<-entered
+	go func() {
+		defer wg.Done()
+		_ = r.Reload()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	compare[*LeapSecondCalculatorTable](t, "Current", tableNew, r.Current())
+	compareError(t, "Close", nil, r.Close())
+}
+
+func TestLeapSecondCalculatorSmeared(t *testing.T) {
+	leap := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	type testRow struct {
+		Name   string
+		LSC    LeapSecondCalculatorSmeared
+		Offset time.Duration
+		Expect time.Duration
+	}
+
+	testData := [...]testRow{
+		{Name: "default/before", Offset: -13 * time.Hour, Expect: 27 * time.Second},
+		{Name: "default/start", Offset: -12 * time.Hour, Expect: 27 * time.Second},
+		{Name: "default/quarter", Offset: -6 * time.Hour, Expect: 27*time.Second + 250*time.Millisecond},
+		{Name: "default/midnight", Offset: 0, Expect: 27*time.Second + 500*time.Millisecond},
+		{Name: "default/end", Offset: 12 * time.Hour, Expect: 28 * time.Second},
+		{Name: "default/after", Offset: 13 * time.Hour, Expect: 28 * time.Second},
+		{
+			Name:   "trailing/midway",
+			LSC:    LeapSecondCalculatorSmeared{Window: 1000 * time.Second, Before: 1000 * time.Second},
+			Offset: -500 * time.Second,
+			Expect: 27*time.Second + 500*time.Millisecond,
+		},
+		{
+			Name:   "trailing/midnight",
+			LSC:    LeapSecondCalculatorSmeared{Window: 1000 * time.Second, Before: 1000 * time.Second},
+			Offset: 0,
+			Expect: 28 * time.Second,
+		},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Name)
+		t.Run(testName, func(t *testing.T) {
+			now := leap.Add(row.Offset)
+			actual := row.LSC.LeapSecondOffsetSinceUnixEpoch(now.Unix(), now.Nanosecond(), false)
+			compare[time.Duration](t, "LeapSecondOffsetSinceUnixEpoch", row.Expect, actual)
+
+			ticks := goTimeToGregorianTicks(row.LSC, now)
+			compare[time.Time](t, "gregorianTicksToGoTime", now, gregorianTicksToGoTime(row.LSC, ticks).UTC())
+		})
+	}
+
+	var lsc LeapSecondCalculatorSmeared
+	var lastTicks uint64
+	for now := leap.Add(-13 * time.Hour); now.Before(leap.Add(13 * time.Hour)); now = now.Add(time.Second) {
+		ticks := goTimeToGregorianTicks(lsc, now)
+		if ticks <= lastTicks {
+			t.Fatalf("ticks are not monotonic at %v: %d <= %d", now, ticks, lastTicks)
+		}
+		lastTicks = ticks
+	}
+}
+
+func TestTimeToTAI(t *testing.T) {
+	type testRow struct {
+		Name   string
+		Time   time.Time
+		TAI    int64
+		GPS    int64
+		Offset time.Duration
+	}
+
+	testData := [...]testRow{
+		{
+			Name:   "GPS epoch",
+			Time:   time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC),
+			TAI:    315964819,
+			GPS:    0,
+			Offset: 19 * time.Second,
+		},
+		{
+			Name:   "before 2017 leap",
+			Time:   time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC),
+			TAI:    1483228835,
+			GPS:    1167264016,
+			Offset: 36 * time.Second,
+		},
+		{
+			Name:   "after 2017 leap",
+			Time:   time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+			TAI:    1483228837,
+			GPS:    1167264018,
+			Offset: 37 * time.Second,
+		},
+		{
+			Name:   "2022",
+			Time:   time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+			TAI:    1640995237,
+			GPS:    1325030418,
+			Offset: 37 * time.Second,
+		},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Name)
+		t.Run(testName, func(t *testing.T) {
+			tai, nanos := TimeToTAI(row.Time, nil)
+			compare[int64](t, "TimeToTAI", row.TAI, tai)
+			compare[int](t, "TimeToTAI/nanos", 0, nanos)
+			compare[time.Time](t, "TimeFromTAI", row.Time, TimeFromTAI(tai, nanos, nil).UTC())
+
+			gps, nanos := TimeToGPS(row.Time, nil)
+			compare[int64](t, "TimeToGPS", row.GPS, gps)
+			compare[int](t, "TimeToGPS/nanos", 0, nanos)
+			compare[time.Time](t, "TimeFromGPS", row.Time, TimeFromGPS(gps, nanos, nil).UTC())
+
+			compare[time.Duration](t, "TAIMinusUTC", row.Offset, TAIMinusUTC(row.Time, nil))
+		})
+	}
+}
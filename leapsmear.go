@@ -0,0 +1,143 @@
+package youyouayedee
+
+import (
+	"math/bits"
+	"time"
+)
+
+// FractionalLeapSecondCalculator is an optional extension of the
+// LeapSecondCalculator interface for calculators which can report the
+// difference between Unix time and SI time with sub-second precision.
+//
+// Time-based UUID generators and UUID.Decode check for this interface, and if
+// it is present, they use it in preference to the integral
+// LeapSecondsSinceUnixEpoch method.
+//
+type FractionalLeapSecondCalculator interface {
+	LeapSecondCalculator
+
+	// LeapSecondOffsetSinceUnixEpoch is like LeapSecondsSinceUnixEpoch,
+	// but it takes the input time as seconds plus nanoseconds and returns
+	// the offset with nanosecond precision.
+	LeapSecondOffsetSinceUnixEpoch(seconds int64, nanos int, includesLeapSeconds bool) time.Duration
+}
+
+// LeapSecondCalculatorSmeared is a FractionalLeapSecondCalculator for hosts
+// whose clocks "smear" each leap second across a window of time, rather than
+// inserting or deleting a whole second at once, as done by Google's and
+// Amazon's public NTP services.
+//
+// During the smear window, the host's clock runs slightly slow (or fast, for
+// a negative leap second), so the offset between the host's clock and SI
+// time changes linearly over the window instead of jumping by a whole second
+// at midnight UTC.  Outside the smear window, the offset is the same as the
+// one reported by Base.
+//
+type LeapSecondCalculatorSmeared struct {
+	// Base provides the table of leap seconds.  If nil, then
+	// LeapSecondCalculatorFixed is used.
+	Base LeapSecondCalculator
+
+	// Window is the length of the smear as measured by the host's clock.
+	// If Window is zero, negative, or longer than 24 hours, then the
+	// standard 24 hour smear from noon to noon UTC is used and Before is
+	// ignored.
+	Window time.Duration
+
+	// Before is how long before the leap second the smear begins, as
+	// measured by the host's clock.  It is clamped to the range
+	// [0, Window].
+	Before time.Duration
+}
+
+func (lsc LeapSecondCalculatorSmeared) LeapSecondsSinceUnixEpoch(seconds int64, includesLeapSeconds bool) int {
+	offset := lsc.LeapSecondOffsetSinceUnixEpoch(seconds, 0, includesLeapSeconds)
+	return int(offset / time.Second)
+}
+
+func (lsc LeapSecondCalculatorSmeared) LeapSecondOffsetSinceUnixEpoch(seconds int64, nanos int, includesLeapSeconds bool) time.Duration {
+	base := lsc.Base
+	if base == nil {
+		base = LeapSecondCalculatorFixed{}
+	}
+
+	window, before := lsc.Window, lsc.Before
+	if window <= 0 || window > 24*time.Hour {
+		window, before = 24*time.Hour, 12*time.Hour
+	}
+	if before < 0 {
+		before = 0
+	}
+	if before > window {
+		before = window
+	}
+
+	// Leap seconds only ever take effect at midnight UTC, and the window
+	// is at most one day long, so the only candidates are the midnights
+	// nearest to the input.
+	beforeSeconds := int64(before / time.Second)
+	m0 := floorDiv(seconds+beforeSeconds, 86400) * 86400
+	for _, m := range [...]int64{m0 - 86400, m0, m0 + 86400} {
+		prev := base.LeapSecondsSinceUnixEpoch(m-1, false)
+		next := base.LeapSecondsSinceUnixEpoch(m, false)
+		delta := next - prev
+		if delta == 0 {
+			continue
+		}
+
+		// Offsets of the input and of the window relative to the
+		// midnight, in nanoseconds.
+		input := (seconds-m)*nanosPerSecond + int64(nanos)
+		start := -int64(before)
+		length := int64(window)
+		if includesLeapSeconds {
+			// On the SI time scale, the window starts after the
+			// previous leap seconds and lasts delta seconds longer.
+			input -= int64(prev) * nanosPerSecond
+			length += int64(delta) * nanosPerSecond
+		}
+
+		elapsed := input - start
+		if elapsed < 0 || elapsed >= length {
+			continue
+		}
+
+		var smear int64
+		if includesLeapSeconds {
+			// elapsed is SI time; scale it down to host time, and
+			// the difference is the smeared portion of the offset.
+			host := mulDiv63(elapsed, int64(window), length)
+			smear = elapsed - host
+		} else {
+			smear = mulDiv63(elapsed, int64(delta)*nanosPerSecond, length)
+		}
+		return time.Duration(int64(prev)*nanosPerSecond + smear)
+	}
+
+	return time.Duration(base.LeapSecondsSinceUnixEpoch(seconds, includesLeapSeconds)) * time.Second
+}
+
+var _ FractionalLeapSecondCalculator = LeapSecondCalculatorSmeared{}
+
+func floorDiv(a int64, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// mulDiv63 computes (a * b / c) without intermediate overflow, rounding
+// toward zero.  Requires a >= 0, c > 0, and |a * b / c| < 2**63.
+func mulDiv63(a int64, b int64, c int64) int64 {
+	neg := b < 0
+	if neg {
+		b = -b
+	}
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	q, _ := bits.Div64(hi, lo, uint64(c))
+	if neg {
+		return -int64(q)
+	}
+	return int64(q)
+}
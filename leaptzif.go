@@ -0,0 +1,205 @@
+package youyouayedee
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// zoneInfoDirs lists the directories which LoadSystemLeapSeconds searches for
+// the system's timezone database, in order.  The "ZONEINFO" environment
+// variable, if set, is searched before any of these.
+var zoneInfoDirs = [...]string{
+	"/usr/share/zoneinfo",
+	"/usr/lib/zoneinfo",
+	"/usr/share/lib/zoneinfo",
+	"/etc/zoneinfo",
+}
+
+// unixTimeOf1972 is the Unix time of 1972-01-01T00:00:00Z, when UTC first
+// became an integral number of seconds behind TAI.
+const unixTimeOf1972 = 63072000
+
+// LoadSystemLeapSeconds reads the leap second records from the system's
+// timezone database, which is typically maintained by the OS distribution.
+//
+// It looks for the "right/UTC" TZif file in the directory named by the
+// "ZONEINFO" environment variable and then in each of the usual locations
+// for the timezone database ("/usr/share/zoneinfo", "/usr/lib/zoneinfo",
+// "/usr/share/lib/zoneinfo", and "/etc/zoneinfo"), and returns the first one
+// found.  See LoadTZifLeapSeconds for details.
+//
+func LoadSystemLeapSeconds() (*LeapSecondCalculatorTable, error) {
+	dirs := make([]string, 0, len(zoneInfoDirs)+1)
+	if dir := os.Getenv("ZONEINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	dirs = append(dirs, zoneInfoDirs[:]...)
+
+	for _, dir := range dirs {
+		fileName := filepath.Join(dir, "right", "UTC")
+		lsc, err := LoadTZifLeapSeconds(fileName)
+		if err == nil {
+			return lsc, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, ErrOperationFailed{Operation: FindSystemLeapSecondsOp, Err: fs.ErrNotExist}
+}
+
+// LoadTZifLeapSeconds reads the leap second records from a TZif file, such
+// as "/usr/share/zoneinfo/right/UTC".
+//
+// See ParseTZifLeapSeconds for details.
+//
+func LoadTZifLeapSeconds(fileName string) (*LeapSecondCalculatorTable, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, ErrOperationFailed{Operation: OpenLeapSecondFileOp, Err: err}
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return parseTZifLeapSeconds(fileName, f)
+}
+
+// ParseTZifLeapSeconds reads the leap second records from data in the TZif
+// format described by RFC 8536.
+//
+// Only the "right/" variants of the zone files contain leap second records.
+// If the data contains no leap second records at all, or if the records are
+// malformed, then ErrLeapSecondDataNotValid is returned.
+//
+// If the data is in TZif version 4 format or later and it ends with an
+// expiration record, then the returned calculator will report that
+// expiration time.  Otherwise the expiration time is unknown.
+//
+func ParseTZifLeapSeconds(r io.Reader) (*LeapSecondCalculatorTable, error) {
+	return parseTZifLeapSeconds("", r)
+}
+
+func parseTZifLeapSeconds(name string, r io.Reader) (*LeapSecondCalculatorTable, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, ErrOperationFailed{Operation: ReadLeapSecondDataOp, Err: err}
+	}
+
+	fail := func(format string, args ...interface{}) error {
+		return ErrLeapSecondDataNotValid{Name: name, Message: fmt.Sprintf(format, args...)}
+	}
+
+	const headerLen = 44
+	if len(raw) < headerLen || string(raw[0:4]) != "TZif" {
+		return nil, fail("not a TZif file")
+	}
+
+	// The version 1 data block uses 32-bit times.  Versions 2 and later
+	// follow it with a second header and a data block that uses 64-bit
+	// times, which is the one we want.
+	version := raw[4]
+	header, data := raw[0:headerLen], raw[headerLen:]
+	timeLen := 4
+	if version >= '2' {
+		skip := tzifDataLen(header, 4)
+		if len(data) < skip+headerLen {
+			return nil, fail("truncated TZif version 1 data block")
+		}
+		header, data = data[skip:skip+headerLen], data[skip+headerLen:]
+		timeLen = 8
+	}
+
+	leapcnt := int(binary.BigEndian.Uint32(header[28:32]))
+	timecnt := int(binary.BigEndian.Uint32(header[32:36]))
+	typecnt := int(binary.BigEndian.Uint32(header[36:40]))
+	charcnt := int(binary.BigEndian.Uint32(header[40:44]))
+
+	if len(data) < tzifDataLen(header, timeLen) {
+		return nil, fail("truncated TZif data block")
+	}
+	if leapcnt == 0 {
+		return nil, fail("no leap second records found; is this a \"right/\" zone file?")
+	}
+
+	offset := timecnt*timeLen + timecnt + typecnt*6 + charcnt
+	recordLen := timeLen + 4
+
+	table := make([]leapSecond, 1, leapcnt+2)
+	table[0] = leapSecond{Time: -(1 << 63), Total: 0}
+
+	// TZif files count leap seconds starting from the first actual leap
+	// second on 1972-06-30, but fixedLeapSecondTable also counts the
+	// integral-second realignment of UTC on 1972-01-01.  We add a
+	// synthetic row for the latter so that the two agree.
+	table = append(table, leapSecond{Time: unixTimeOf1972, Total: 1})
+
+	var expires int64
+	var hasExpires bool
+	lastCorr := int64(0)
+	for li := 0; li < leapcnt; li++ {
+		record := data[offset+li*recordLen : offset+(li+1)*recordLen]
+
+		var occur int64
+		if timeLen == 8 {
+			occur = int64(binary.BigEndian.Uint64(record[0:8]))
+		} else {
+			occur = int64(int32(binary.BigEndian.Uint32(record[0:4])))
+		}
+		corr := int64(int32(binary.BigEndian.Uint32(record[timeLen : timeLen+4])))
+
+		// The occurrence time is expressed on a time scale that counts
+		// the leap seconds which came before it.
+		t := occur - lastCorr
+
+		if li != 0 && t <= table[len(table)-1].Time {
+			return nil, fail("leap second record %d: occurrence times are not strictly increasing", li)
+		}
+
+		delta := corr - lastCorr
+		switch {
+		case delta == 0 && li != 0 && li == leapcnt-1:
+			// Version 4 expiration record.
+			expires = t
+			hasExpires = true
+			continue
+
+		case li == 0:
+			// The first record may have an arbitrary correction if the
+			// data was truncated at the start.
+
+		case delta != 1 && delta != -1:
+			return nil, fail("leap second record %d: correction changed by %d seconds; expected 1 or -1", li, delta)
+		}
+
+		if t <= unixTimeOf1972 {
+			return nil, fail("leap second record %d: occurrence time precedes 1972", li)
+		}
+
+		table = append(table, leapSecond{Time: t, Total: int(corr) + 1})
+		lastCorr = corr
+	}
+
+	lsc := &LeapSecondCalculatorTable{table: table}
+	if hasExpires {
+		lsc.expires = time.Unix(expires, 0).UTC()
+	}
+	return lsc, nil
+}
+
+func tzifDataLen(header []byte, timeLen int) int {
+	isutcnt := int(binary.BigEndian.Uint32(header[20:24]))
+	isstdcnt := int(binary.BigEndian.Uint32(header[24:28]))
+	leapcnt := int(binary.BigEndian.Uint32(header[28:32]))
+	timecnt := int(binary.BigEndian.Uint32(header[32:36]))
+	typecnt := int(binary.BigEndian.Uint32(header[36:40]))
+	charcnt := int(binary.BigEndian.Uint32(header[40:44]))
+	return timecnt*timeLen + timecnt + typecnt*6 + charcnt + leapcnt*(timeLen+4) + isstdcnt + isutcnt
+}
@@ -98,15 +98,120 @@ func (text TextUUID) Value() (driver.Value, error) {
 	return text.UUID.String(), nil
 }
 
+// GUID is a wrapper type for UUID that stores and loads binary data using the
+// mixed-endian byte order of a Microsoft GUID structure, as used by Windows
+// APIs, .NET, and SQL Server's uniqueidentifier type.  See AppendGUIDBytes.
+//
+// Its text representation is the same as that of UUID, except that UUIDs of
+// any variant are accepted when parsing.
+//
+type GUID struct {
+	UUID UUID
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (guid GUID) MarshalText() ([]byte, error) {
+	return guid.UUID.MarshalText()
+}
+
+// MarshalBinary fulfills the "encoding".BinaryMarshaler interface.
+func (guid GUID) MarshalBinary() ([]byte, error) {
+	return guid.UUID.AppendGUIDBytes(make([]byte, 0, Size)), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (guid GUID) MarshalJSON() ([]byte, error) {
+	return guid.UUID.MarshalJSON()
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (guid *GUID) UnmarshalText(text []byte) error {
+	var err error
+	guid.UUID, err = guidParseOptions.parse(text, false)
+	return err
+}
+
+// UnmarshalBinary fulfills the "encoding".BinaryUnmarshaler interface.
+func (guid *GUID) UnmarshalBinary(data []byte) error {
+	var err error
+	guid.UUID, err = FromGUIDBytes(data)
+	return err
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (guid *GUID) UnmarshalJSON(data []byte) error {
+	guid.UUID = Nil
+
+	if len(data) == 4 && string(data) == "null" {
+		return nil
+	}
+
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		guid.UUID, err = guidParseOptions.Parse(str)
+	}
+	return err
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+//
+// A 16-byte []byte is interpreted as a mixed-endian GUID structure.  Strings
+// and []byte values of other lengths are parsed as text.
+//
+func (guid *GUID) Scan(value interface{}) error {
+	var err error
+	guid.UUID = Nil
+	switch x := value.(type) {
+	case nil:
+		err = nil
+
+	case string:
+		guid.UUID, err = guidParseOptions.Parse(x)
+
+	case []byte:
+		if len(x) == Size {
+			guid.UUID, err = FromGUIDBytes(x)
+		} else {
+			guid.UUID, err = guidParseOptions.parse(x, false)
+		}
+
+	default:
+		err = fmt.Errorf("don't know how to interpret a value of type %T as a GUID", value)
+	}
+	return err
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (guid GUID) Value() (driver.Value, error) {
+	return guid.UUID.AppendGUIDBytes(make([]byte, 0, Size)), nil
+}
+
+// String formats the GUID using the standard UUID string representation.
+func (guid GUID) String() string {
+	return guid.UUID.String()
+}
+
+var guidParseOptions = ParseOptions{AcceptAnyVariant: true}
+
 var (
 	_ encoding.TextMarshaler     = UUID{}
 	_ encoding.BinaryMarshaler   = UUID{}
 	_ json.Marshaler             = UUID{}
 	_ driver.Valuer              = UUID{}
 	_ driver.Valuer              = TextUUID{}
+	_ encoding.TextMarshaler     = GUID{}
+	_ encoding.BinaryMarshaler   = GUID{}
+	_ json.Marshaler             = GUID{}
+	_ driver.Valuer              = GUID{}
+	_ fmt.Stringer               = GUID{}
 	_ encoding.TextUnmarshaler   = (*UUID)(nil)
 	_ encoding.BinaryUnmarshaler = (*UUID)(nil)
 	_ json.Unmarshaler           = (*UUID)(nil)
 	_ sql.Scanner                = (*UUID)(nil)
 	_ sql.Scanner                = (*TextUUID)(nil)
+	_ encoding.TextUnmarshaler   = (*GUID)(nil)
+	_ encoding.BinaryUnmarshaler = (*GUID)(nil)
+	_ json.Unmarshaler           = (*GUID)(nil)
+	_ sql.Scanner                = (*GUID)(nil)
 )
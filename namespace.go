@@ -0,0 +1,81 @@
+package youyouayedee
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// NamespaceDNS is the well-known namespace for fully qualified domain names,
+// "6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+var NamespaceDNS = UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// NamespaceURL is the well-known namespace for URLs,
+// "6ba7b811-9dad-11d1-80b4-00c04fd430c8".
+var NamespaceURL = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// NamespaceOID is the well-known namespace for ISO object identifiers,
+// "6ba7b812-9dad-11d1-80b4-00c04fd430c8".
+var NamespaceOID = UUID{0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// NamespaceX500 is the well-known namespace for X.500 distinguished names,
+// "6ba7b814-9dad-11d1-80b4-00c04fd430c8".
+var NamespaceX500 = UUID{0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+type hashAlgorithmInfo struct {
+	space UUID
+	fn    func() hash.Hash
+}
+
+// hashAlgorithmInfoArray holds the hash space IDs from RFC 9562 Section 6.6.
+var hashAlgorithmInfoArray = [...]hashAlgorithmInfo{
+	{},
+	{UUID{0x59, 0x03, 0x1c, 0xa3, 0xfb, 0xdb, 0x47, 0xfb, 0x9f, 0x6c, 0x0f, 0x30, 0xe2, 0xe8, 0x31, 0x45}, sha256.New224},
+	{UUID{0x3f, 0xb3, 0x27, 0x80, 0x95, 0x3c, 0x44, 0x64, 0x9c, 0xfd, 0xe8, 0x5d, 0xbb, 0xe9, 0x84, 0x3d}, sha256.New},
+	{UUID{0xe6, 0x80, 0x05, 0x81, 0xf3, 0x33, 0x48, 0x4b, 0x87, 0x78, 0x60, 0x1f, 0xf2, 0xb5, 0x8d, 0xa8}, sha512.New384},
+	{UUID{0x0f, 0xde, 0x22, 0xf2, 0xe7, 0xba, 0x4f, 0xd1, 0x97, 0x53, 0x9c, 0x2e, 0xa8, 0x8f, 0xa3, 0xf9}, sha512.New},
+	{UUID{0x00, 0x3c, 0x20, 0x38, 0xc4, 0xfe, 0x4b, 0x95, 0xa6, 0x72, 0x0c, 0x26, 0xc1, 0xb7, 0x95, 0x42}, sha512.New512_224},
+	{UUID{0x94, 0x75, 0xad, 0x00, 0x37, 0x69, 0x4c, 0x07, 0x96, 0x42, 0x5e, 0x73, 0x83, 0x73, 0x23, 0x06}, sha512.New512_256},
+	{UUID{0x97, 0x68, 0x76, 0x1f, 0xac, 0x5a, 0x41, 0x9e, 0xa1, 0x80, 0x7c, 0xa2, 0x39, 0xe8, 0x02, 0x5a}, sha3.New224},
+	{UUID{0x20, 0x34, 0xd6, 0x6b, 0x40, 0x47, 0x45, 0x53, 0x8f, 0x80, 0x70, 0xe5, 0x93, 0x17, 0x68, 0x77}, sha3.New256},
+	{UUID{0x87, 0x2f, 0xb3, 0x39, 0x26, 0x36, 0x4b, 0xdd, 0xbd, 0xa6, 0xb6, 0xdc, 0x2a, 0x82, 0xb1, 0xb3}, sha3.New384},
+	{UUID{0xa4, 0x92, 0x0a, 0x5d, 0xa8, 0xa6, 0x42, 0x6c, 0x8d, 0x14, 0xa6, 0xca, 0xfb, 0xe6, 0x4c, 0x7b}, sha3.New512},
+}
+
+// HashSpace returns the hash space ID which RFC 9562 assigns to this hash
+// algorithm, or Nil if the algorithm is not valid.
+//
+// Hash space IDs are not part of the hash input.  They let applications
+// record or communicate which algorithm produced a given name-based V8 UUID.
+//
+func (enum HashAlgorithm) HashSpace() UUID {
+	if !enum.IsValid() {
+		return Nil
+	}
+	return hashAlgorithmInfoArray[enum].space
+}
+
+// Factory returns a callback which produces new instances of hash.Hash for
+// this hash algorithm, or nil if the algorithm is not valid.  The result is
+// suitable for Options.HashFactory.
+//
+func (enum HashAlgorithm) Factory() func() hash.Hash {
+	if !enum.IsValid() {
+		return nil
+	}
+	return hashAlgorithmInfoArray[enum].fn
+}
+
+// HashAlgorithmForSpace returns the hash algorithm with the given RFC 9562
+// hash space ID.
+func HashAlgorithmForSpace(space UUID) (HashAlgorithm, bool) {
+	for index := range hashAlgorithmInfoArray {
+		algo := HashAlgorithm(index)
+		if algo.IsValid() && hashAlgorithmInfoArray[index].space == space {
+			return algo, true
+		}
+	}
+	return 0, false
+}
@@ -2,6 +2,8 @@ package youyouayedee
 
 import (
 	"fmt"
+	"net"
+	"path"
 	"sort"
 )
 
@@ -94,18 +96,82 @@ func (node Node) AppendTo(out []byte) []byte {
 	return out
 }
 
+// HardwareAddress describes a network interface hardware address which is a
+// candidate for use as a node identifier.
+type HardwareAddress struct {
+	// Node is the node identifier derived from the hardware address.
+	Node Node
+
+	// Type indicates whether Node was copied from an EUI-48 address or
+	// derived from a MAC-based EUI-64 address.
+	Type HardwareAddressType
+
+	// Interface is the name of the network interface, e.g. "eth0".
+	Interface string
+
+	// Index is the OS-assigned index of the network interface.
+	Index int
+
+	// Flags holds the flags of the network interface.
+	Flags net.Flags
+}
+
+// IsGlobal returns true iff the Node is globally unique.  See Node.IsGlobal.
+func (addr HardwareAddress) IsGlobal() bool {
+	return addr.Node.IsGlobal()
+}
+
+// IsUnicast returns true iff the Node is a unicast address.  See
+// Node.IsUnicast.
+func (addr HardwareAddress) IsUnicast() bool {
+	return addr.Node.IsUnicast()
+}
+
+// ListHardwareAddresses returns every candidate node identifier that can be
+// derived from the current host's EUI-48 and EUI-64 network addresses, after
+// applying the interface filters in Options.
+//
+// The list is sorted from most preferred to least preferred: globally unique
+// addresses come before locally administered ones, unicast addresses come
+// before multicast ones, and ties are broken by interface index.  Loopback
+// interfaces are never included.
+//
+func ListHardwareAddresses(o Options) ([]HardwareAddress, error) {
+	for _, pattern := range o.IncludeInterfaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, ErrOperationFailed{Operation: MatchInterfaceNameOp, Err: err}
+		}
+	}
+	for _, pattern := range o.ExcludeInterfaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, ErrOperationFailed{Operation: MatchInterfaceNameOp, Err: err}
+		}
+	}
+
+	list, err := listInterfaces()
+	if err != nil {
+		return nil, err
+	}
+	return collectHardwareAddresses(list, o), nil
+}
+
 // GenerateNode returns the best available node identifier given the current
 // host's EUI-48 and EUI-64 network addresses, or else it generates one at
 // random as a fallback.
+//
+// The candidate addresses are those returned by ListHardwareAddresses, so the
+// interface filters in Options can be used to pin the choice to a specific
+// network interface.
+//
 func GenerateNode(o Options) (Node, error) {
 	var node Node
 	if !o.ForceRandomNode {
-		nodes, err := listHardwareAddresses()
+		addrs, err := ListHardwareAddresses(o)
 		if err != nil {
 			return NilNode, err
 		}
-		if len(nodes) > 0 {
-			return nodes[0], nil
+		if len(addrs) > 0 {
+			return addrs[0].Node, nil
 		}
 	}
 
@@ -123,14 +189,7 @@ var (
 	_ fmt.Stringer   = Node{}
 )
 
-type hwaddrCandidate struct {
-	Node      Node
-	Index     int
-	IsGlobal  bool
-	IsUnicast bool
-}
-
-type hwaddrCandidates []hwaddrCandidate
+type hwaddrCandidates []HardwareAddress
 
 func (list hwaddrCandidates) Len() int {
 	return len(list)
@@ -143,11 +202,13 @@ func (list hwaddrCandidates) Swap(i, j int) {
 func (list hwaddrCandidates) Less(i, j int) bool {
 	a := list[i]
 	b := list[j]
-	if a.IsGlobal != b.IsGlobal {
-		return a.IsGlobal
+	aGlobal, bGlobal := a.IsGlobal(), b.IsGlobal()
+	if aGlobal != bGlobal {
+		return aGlobal
 	}
-	if a.IsUnicast != b.IsUnicast {
-		return a.IsUnicast
+	aUnicast, bUnicast := a.IsUnicast(), b.IsUnicast()
+	if aUnicast != bUnicast {
+		return aUnicast
 	}
 	return a.Index < b.Index
 }
@@ -158,7 +219,62 @@ func (list hwaddrCandidates) Sort() {
 
 var _ sort.Interface = hwaddrCandidates(nil)
 
-func convertHardwareAddrToNode(hwaddr []byte) (Node, bool) {
+func collectHardwareAddresses(list []net.Interface, o Options) []HardwareAddress {
+	candidates := make(hwaddrCandidates, 0, len(list))
+	for _, iface := range list {
+		if (iface.Flags & net.FlagLoopback) != 0 {
+			continue
+		}
+
+		if !interfaceMatches(iface, o) {
+			continue
+		}
+
+		if node, hwtype, ok := convertHardwareAddrToNode([]byte(iface.HardwareAddr)); ok {
+			candidates = append(candidates, HardwareAddress{
+				Node:      node,
+				Type:      hwtype,
+				Interface: iface.Name,
+				Index:     iface.Index,
+				Flags:     iface.Flags,
+			})
+		}
+	}
+
+	candidates.Sort()
+	return []HardwareAddress(candidates)
+}
+
+func interfaceMatches(iface net.Interface, o Options) bool {
+	if (iface.Flags & o.RequireInterfaceFlags) != o.RequireInterfaceFlags {
+		return false
+	}
+
+	if (iface.Flags & o.RejectInterfaceFlags) != 0 {
+		return false
+	}
+
+	if len(o.IncludeInterfaces) != 0 && !matchAnyPattern(o.IncludeInterfaces, iface.Name) {
+		return false
+	}
+
+	if matchAnyPattern(o.ExcludeInterfaces, iface.Name) {
+		return false
+	}
+
+	return true
+}
+
+func matchAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func convertHardwareAddrToNode(hwaddr []byte) (Node, HardwareAddressType, bool) {
 	hwaddrLen := uint(len(hwaddr))
 
 	// EUI-48
@@ -166,7 +282,7 @@ func convertHardwareAddrToNode(hwaddr []byte) (Node, bool) {
 		var node Node
 		copy(node[:], hwaddr)
 		if !node.IsZero() {
-			return node, true
+			return node, EUI48, true
 		}
 	}
 
@@ -180,9 +296,9 @@ func convertHardwareAddrToNode(hwaddr []byte) (Node, bool) {
 		node[4] = hwaddr[6]
 		node[5] = hwaddr[7]
 		if !node.IsZero() {
-			return node, true
+			return node, EUI64, true
 		}
 	}
 
-	return NilNode, false
+	return NilNode, 0, false
 }
@@ -2,6 +2,8 @@ package youyouayedee
 
 import (
 	"fmt"
+	"net"
+	"strings"
 	"testing"
 )
 
@@ -39,3 +41,76 @@ func TestNode(t *testing.T) {
 		})
 	}
 }
+
+func TestListHardwareAddresses(t *testing.T) {
+	ifaces := []net.Interface{
+		{Index: 1, Name: "lo", Flags: net.FlagUp | net.FlagLoopback},
+		{Index: 2, Name: "docker0", Flags: net.FlagUp | net.FlagBroadcast, HardwareAddr: net.HardwareAddr{0x02, 0x42, 0xac, 0x11, 0x00, 0x01}},
+		{Index: 3, Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast, HardwareAddr: net.HardwareAddr{0x00, 0x1b, 0x21, 0x3a, 0x4c, 0x5d}},
+		{Index: 4, Name: "eth1", Flags: net.FlagBroadcast, HardwareAddr: net.HardwareAddr{0x00, 0x1b, 0x21, 0x3a, 0x4c, 0x5e}},
+		{Index: 5, Name: "ib0", Flags: net.FlagUp, HardwareAddr: net.HardwareAddr{0x02, 0x1b, 0x21, 0xff, 0xfe, 0x3a, 0x4c, 0x5f}},
+	}
+
+	type testRow struct {
+		Name    string
+		Options Options
+		Expect  []string
+	}
+
+	testData := [...]testRow{
+		{
+			Name:   "default",
+			Expect: []string{"eth0", "eth1", "ib0", "docker0"},
+		},
+		{
+			Name:    "exclude",
+			Options: Options{ExcludeInterfaces: []string{"docker*", "ib*"}},
+			Expect:  []string{"eth0", "eth1"},
+		},
+		{
+			Name:    "include",
+			Options: Options{IncludeInterfaces: []string{"eth1", "docker?"}},
+			Expect:  []string{"eth1", "docker0"},
+		},
+		{
+			Name:    "include-and-exclude",
+			Options: Options{IncludeInterfaces: []string{"eth*"}, ExcludeInterfaces: []string{"eth0"}},
+			Expect:  []string{"eth1"},
+		},
+		{
+			Name:    "require-flags",
+			Options: Options{RequireInterfaceFlags: net.FlagUp},
+			Expect:  []string{"eth0", "ib0", "docker0"},
+		},
+		{
+			Name:    "reject-flags",
+			Options: Options{RejectInterfaceFlags: net.FlagBroadcast},
+			Expect:  []string{"ib0"},
+		},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Name)
+		t.Run(testName, func(t *testing.T) {
+			addrs := collectHardwareAddresses(ifaces, row.Options)
+			actual := make([]string, len(addrs))
+			for i, addr := range addrs {
+				actual[i] = addr.Interface
+			}
+			compare[string](t, "Interfaces", strings.Join(row.Expect, ","), strings.Join(actual, ","))
+		})
+	}
+
+	addrs := collectHardwareAddresses(ifaces, Options{IncludeInterfaces: []string{"ib0"}})
+	if len(addrs) != 1 {
+		t.Fatalf("ib0: expected 1 address, got %d", len(addrs))
+	}
+	compare[HardwareAddressType](t, "Type", EUI64, addrs[0].Type)
+	compare[Node](t, "Node", Node{0x00, 0x1b, 0x21, 0x3a, 0x4c, 0x5f}, addrs[0].Node)
+	compare[net.Flags](t, "Flags", net.FlagUp, addrs[0].Flags)
+
+	_, err := ListHardwareAddresses(Options{ExcludeInterfaces: []string{"["}})
+	if err == nil {
+		t.Errorf("ListHardwareAddresses: expected error for malformed pattern")
+	}
+}
@@ -3,6 +3,7 @@ package youyouayedee
 import (
 	"hash"
 	"io"
+	"net"
 	"time"
 )
 
@@ -70,16 +71,67 @@ type Options struct {
 	// HashFactory is a callback to produce new instances of hash.Hash on demand.
 	//
 	// Only hash-based UUID generators for V8 UUIDs use this field, and for
-	// that case the field is mandatory.  V3 and V5 UUID generators ignore
-	// this field and always use md5.New or sha1.New, respectively.
+	// that case either this field or HashAlgorithm is mandatory.  V3 and V5
+	// UUID generators ignore this field and always use md5.New or
+	// sha1.New, respectively.
 	//
 	HashFactory func() hash.Hash
 
+	// HashAlgorithm selects one of the RFC 9562 hash algorithms for
+	// hash-based V8 UUIDs.  It is only used if HashFactory is nil.
+	//
+	// RFC 9562 Appendix B uses HashSHA256 for its name-based V8 example.
+	//
+	HashAlgorithm HashAlgorithm
+
+	// CanonicalizeNames causes hash-based UUID generators to convert names
+	// into canonical form before hashing, using the NameCanonicalizer
+	// returned by CanonicalizerForNamespace.  It has no effect unless
+	// Namespace is one of the well-known namespaces, such as NamespaceDNS.
+	//
+	// Canonicalization applies to NewHashUUID, NewHashUUIDFromReader, and
+	// NewHashUUIDInNamespace, and to the first name passed to
+	// NewHashUUIDFromPath.  It does not apply to the components passed to
+	// NewHashUUIDFromParts.
+	//
+	CanonicalizeNames bool
+
 	// ForceRandomNode controls the behavior of GenerateNode when a node
 	// identifier is required but Node is the zero value.
 	//
 	ForceRandomNode bool
 
+	// IncludeInterfaces restricts the network interfaces considered by
+	// ListHardwareAddresses and GenerateNode to those whose names match
+	// at least one of the given "path".Match patterns, e.g. "eth*" or
+	// "enp0s31f6".
+	//
+	// If this field is empty, then all interfaces are considered.
+	//
+	IncludeInterfaces []string
+
+	// ExcludeInterfaces removes the network interfaces whose names match
+	// any of the given "path".Match patterns, e.g. "docker*" or "veth*",
+	// from consideration by ListHardwareAddresses and GenerateNode.
+	//
+	// Exclusion takes priority over IncludeInterfaces.
+	//
+	ExcludeInterfaces []string
+
+	// RequireInterfaceFlags lists the "net".Flags which a network
+	// interface must have, e.g. "net".FlagUp, in order to be considered by
+	// ListHardwareAddresses and GenerateNode.
+	//
+	RequireInterfaceFlags net.Flags
+
+	// RejectInterfaceFlags lists the "net".Flags which a network interface
+	// must not have, e.g. "net".FlagPointToPoint, in order to be
+	// considered by ListHardwareAddresses and GenerateNode.
+	//
+	// Loopback interfaces are always rejected.
+	//
+	RejectInterfaceFlags net.Flags
+
 	// RandomSource specifies a source of random bytes.
 	//
 	// Both random-based and time-based UUID generators use this field,
@@ -90,3 +142,79 @@ type Options struct {
 	//
 	RandomSource io.Reader
 }
+
+// ParseOptions supplies options for parsing UUID values.
+//
+// The zero value is equivalent to the behavior of the Parse and ParseBytes
+// functions, which are quite lenient.  Each field makes the parser stricter
+// (or, in the case of AcceptAnyVariant, more lenient) in a specific way.  All
+// failures are reported as ErrParseFailed.
+//
+type ParseOptions struct {
+	// Formats lists the input formats which are accepted.
+	//
+	// If this field is empty, then all formats except FormatULID are
+	// accepted.  FormatBinary only applies to ParseBytes, and to the
+	// binary unmarshaling methods.  FormatULID must be listed explicitly,
+	// and is exempt from RequireLowerCase.  Inputs in other formats fail
+	// with DisallowedFormat.
+	//
+	Formats []Format
+
+	// Versions lists the UUID versions which are accepted.
+	//
+	// If this field is empty, then all versions are accepted.  Otherwise,
+	// UUIDs of other versions, or of variants other than RFC 4122, fail
+	// with WrongVersion.  The nil UUID and the max UUID are not subject to
+	// this check; use RejectNilAndMax to exclude them.
+	//
+	Versions []Version
+
+	// RejectKeywords causes the keyword inputs "", "nil", "null", and
+	// "max" to fail with DisallowedKeyword, instead of being parsed as
+	// the nil UUID or the max UUID.
+	//
+	RejectKeywords bool
+
+	// RejectNilAndMax causes the nil UUID and the max UUID to fail with
+	// DisallowedValue, no matter how they are spelled.
+	//
+	RejectNilAndMax bool
+
+	// RequireLowerCase causes inputs which contain upper case ASCII
+	// letters to fail with UnexpectedCharacter.  By default, parsing is
+	// case insensitive.
+	//
+	RequireLowerCase bool
+
+	// AcceptAnyVariant allows UUIDs of any variant to be parsed, such as
+	// legacy Apollo NCS UUIDs or Microsoft COM GUIDs.  By default, only
+	// RFC 4122 variant UUIDs (plus Nil and Max) are accepted, and all
+	// others fail with WrongVariant.
+	//
+	AcceptAnyVariant bool
+}
+
+// StrictParseOptions returns a ParseOptions which only accepts the canonical
+// 36-character lower case form of RFC 4122 variant UUIDs, and which rejects
+// the nil UUID and the max UUID in any form.
+func StrictParseOptions() ParseOptions {
+	return ParseOptions{
+		Formats:          []Format{FormatCanonical},
+		RejectKeywords:   true,
+		RejectNilAndMax:  true,
+		RequireLowerCase: true,
+	}
+}
+
+// Parse parses a UUID from a string.
+func (opts ParseOptions) Parse(str string) (UUID, error) {
+	var tmp [64]byte
+	input := append(tmp[:0], str...)
+	return opts.parse(input, false)
+}
+
+// ParseBytes parses a UUID from a []byte.
+func (opts ParseOptions) ParseBytes(buf []byte) (UUID, error) {
+	return opts.parse(buf, true)
+}
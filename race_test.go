@@ -0,0 +1,8 @@
+//go:build race
+// +build race
+
+package youyouayedee
+
+// raceEnabled is true when the race detector is enabled, which makes
+// sync.Pool drop items at random and so defeats allocation counting.
+const raceEnabled = true
@@ -0,0 +1,259 @@
+package youyouayedee
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// SignedUUIDMACSize is the size in bytes of the truncated MAC in a SignedUUID.
+const SignedUUIDMACSize = 16
+
+// SignedUUIDLength is the length of the text form of a SignedUUID.
+const SignedUUIDLength = 44
+
+// signedUUIDSize is the size of the binary form: UUID, key ID, and MAC.
+const signedUUIDSize = Size + 1 + SignedUUIDMACSize
+
+// signedUUIDContext separates SignedUUID MACs from any other use of the keys.
+const signedUUIDContext = "youyouayedee.SignedUUID\x00"
+
+// SignedUUID is a UUID paired with a MAC, for use in tokens such as
+// unsubscribe links, where the recipient must not be able to substitute a
+// different UUID.  Use a Signer to create and verify SignedUUIDs.
+//
+// Its text and JSON representations are 44 characters of unpadded base64url,
+// encoding the UUID, the key ID, and the MAC.  SQL databases will store it as
+// a 44-character string.
+//
+// The UUID field of a SignedUUID obtained by parsing is not trustworthy until
+// Signer.Verify has succeeded.
+//
+type SignedUUID struct {
+	UUID  UUID
+	KeyID byte
+	MAC   [SignedUUIDMACSize]byte
+}
+
+// ParseSignedUUID parses the text form of a SignedUUID.  The MAC is not
+// verified; see Signer.Verify and Signer.Open.
+//
+func ParseSignedUUID(str string) (SignedUUID, error) {
+	var tmp [64]byte
+	input := append(tmp[:0], str...)
+	return parseSignedUUID(input)
+}
+
+// IsZero returns true iff this is the zero SignedUUID.
+func (su SignedUUID) IsZero() bool {
+	return su == SignedUUID{}
+}
+
+// String returns the SignedUUID in its 44-character text form.
+func (su SignedUUID) String() string {
+	return string(su.AppendTo(make([]byte, 0, SignedUUIDLength)))
+}
+
+// AppendTo appends the SignedUUID's text form to the given []byte.
+func (su SignedUUID) AppendTo(out []byte) []byte {
+	var raw [signedUUIDSize]byte
+	copy(raw[0:Size], su.UUID[:])
+	raw[Size] = su.KeyID
+	copy(raw[Size+1:], su.MAC[:])
+
+	var tmp [SignedUUIDLength]byte
+	base64Encoding.Encode(tmp[:], raw[:])
+	return append(out, tmp[:]...)
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (su SignedUUID) MarshalText() ([]byte, error) {
+	return su.AppendTo(make([]byte, 0, SignedUUIDLength)), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (su SignedUUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(su.String())
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (su *SignedUUID) UnmarshalText(text []byte) error {
+	var err error
+	*su, err = parseSignedUUID(text)
+	return err
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (su *SignedUUID) UnmarshalJSON(data []byte) error {
+	*su = SignedUUID{}
+
+	if len(data) == 4 && string(data) == "null" {
+		return nil
+	}
+
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		*su, err = ParseSignedUUID(str)
+	}
+	return err
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (su *SignedUUID) Scan(value interface{}) error {
+	var err error
+	*su = SignedUUID{}
+	switch x := value.(type) {
+	case nil:
+		err = nil
+
+	case string:
+		*su, err = ParseSignedUUID(x)
+
+	case []byte:
+		*su, err = ParseSignedUUID(string(x))
+
+	default:
+		err = fmt.Errorf("don't know how to interpret a value of type %T as a SignedUUID", value)
+	}
+	return err
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (su SignedUUID) Value() (driver.Value, error) {
+	return su.String(), nil
+}
+
+// Signer creates and verifies SignedUUIDs with HMAC-SHA-256, truncated to
+// SignedUUIDMACSize bytes.
+//
+// New SignedUUIDs are always signed with the active key.  SignedUUIDs signed
+// with any of the Signer's keys are accepted by Verify, so keys can be rotated
+// by making the new key active while keeping the old key until its tokens
+// have expired.
+//
+// Use a separate key for each purpose, so that a token issued for one
+// purpose cannot be replayed for another.
+//
+// A Signer is safe for concurrent use.
+//
+type Signer struct {
+	active byte
+	keys   map[byte][]byte
+}
+
+// NewSigner constructs a new Signer.  The active key signs new SignedUUIDs;
+// the other keys are only used for verification.  Key IDs must be unique, and
+// each secret must be at least MinHashKeySize bytes long.
+//
+func NewSigner(active HashKey, others ...HashKey) (*Signer, error) {
+	keys := make(map[byte][]byte, 1+len(others))
+	for _, key := range append([]HashKey{active}, others...) {
+		if len(key.Secret) < MinHashKeySize {
+			return nil, ErrHashKeyNotValid{Algorithm: KeyedHMACSHA256, KeyID: key.ID, Message: fmt.Sprintf("secret is %d bytes; should be at least %d bytes", len(key.Secret), MinHashKeySize)}
+		}
+		if _, found := keys[key.ID]; found {
+			return nil, ErrHashKeyNotValid{Algorithm: KeyedHMACSHA256, KeyID: key.ID, Message: "duplicate key ID"}
+		}
+		secret := make([]byte, len(key.Secret))
+		copy(secret, key.Secret)
+		keys[key.ID] = secret
+	}
+	return &Signer{active: active.ID, keys: keys}, nil
+}
+
+// Sign returns the SignedUUID for the given UUID, using the active key.
+func (s *Signer) Sign(uuid UUID) SignedUUID {
+	return SignedUUID{UUID: uuid, KeyID: s.active, MAC: signedUUIDMAC(s.keys[s.active], uuid, s.active)}
+}
+
+// Verify checks the MAC of the given SignedUUID in constant time.  It fails
+// with ErrSignatureNotValid if the key ID is unknown or the MAC is wrong.
+//
+func (s *Signer) Verify(su SignedUUID) error {
+	secret, found := s.keys[su.KeyID]
+	if !found {
+		return ErrSignatureNotValid{KeyID: su.KeyID, Message: "unknown key ID"}
+	}
+
+	expected := signedUUIDMAC(secret, su.UUID, su.KeyID)
+	if !hmac.Equal(expected[:], su.MAC[:]) {
+		return ErrSignatureNotValid{KeyID: su.KeyID, Message: "MAC does not match"}
+	}
+	return nil
+}
+
+// Open parses the text form of a SignedUUID and verifies it, returning the
+// UUID only if the MAC is valid.
+//
+func (s *Signer) Open(str string) (UUID, error) {
+	su, err := ParseSignedUUID(str)
+	if err != nil {
+		return Nil, err
+	}
+	if err := s.Verify(su); err != nil {
+		return Nil, err
+	}
+	return su.UUID, nil
+}
+
+func signedUUIDMAC(secret []byte, uuid UUID, keyID byte) [SignedUUIDMACSize]byte {
+	h := hmac.New(sha256.New, secret)
+	_, _ = h.Write([]byte(signedUUIDContext))
+	_, _ = h.Write([]byte{keyID})
+	_, _ = h.Write(uuid[:])
+
+	var sum [sha256.Size]byte
+	var mac [SignedUUIDMACSize]byte
+	copy(mac[:], h.Sum(sum[:0]))
+	return mac
+}
+
+func parseSignedUUID(input []byte) (SignedUUID, error) {
+	inputLen := uint(len(input))
+	if inputLen != SignedUUIDLength {
+		return SignedUUID{}, ErrParseFailed{
+			Input:   input,
+			Problem: WrongInputLength,
+			Args:    mkargs(inputLen, "signed UUID", uint(SignedUUIDLength)),
+		}
+	}
+
+	for ii := uint(0); ii < inputLen; ii++ {
+		ch := input[ii]
+		if base64Decode[ch] == 0xff {
+			return SignedUUID{}, ErrParseFailed{
+				Input:      input,
+				Problem:    UnexpectedCharacter,
+				Args:       mkargs(ch, ii, "base64url digit"),
+				Index:      ii,
+				ActualByte: ch,
+			}
+		}
+	}
+
+	// 33 bytes encode to exactly 44 characters, so there are no padding
+	// bits and every string of valid characters decodes.
+	var raw [signedUUIDSize]byte
+	_, _ = base64Encoding.Decode(raw[:], input)
+
+	var su SignedUUID
+	copy(su.UUID[:], raw[0:Size])
+	su.KeyID = raw[Size]
+	copy(su.MAC[:], raw[Size+1:])
+	return su, nil
+}
+
+var (
+	_ encoding.TextMarshaler   = SignedUUID{}
+	_ json.Marshaler           = SignedUUID{}
+	_ driver.Valuer            = SignedUUID{}
+	_ fmt.Stringer             = SignedUUID{}
+	_ encoding.TextUnmarshaler = (*SignedUUID)(nil)
+	_ json.Unmarshaler         = (*SignedUUID)(nil)
+	_ sql.Scanner              = (*SignedUUID)(nil)
+)
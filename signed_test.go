@@ -0,0 +1,103 @@
+package youyouayedee
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	oldKey := HashKey{ID: 2, Secret: bytes.Repeat([]byte{0x22}, 32)}
+	newKey := HashKey{ID: 3, Secret: bytes.Repeat([]byte{0x11}, 32)}
+
+	s, err := NewSigner(newKey, oldKey)
+	if err != nil {
+		t.Fatalf("NewSigner: unexpected error: %v", err)
+	}
+
+	su := s.Sign(uuidV4)
+	compare[UUID](t, "Sign/UUID", uuidV4, su.UUID)
+	compare[byte](t, "Sign/KeyID", 3, su.KeyID)
+	compare[string](t, "String", "kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49A", su.String())
+	compare[string](t, "AppendTo", "id=kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49A", string(su.AppendTo([]byte("id="))))
+	compareError(t, "Verify", nil, s.Verify(su))
+
+	uuid, err := s.Open(su.String())
+	compareError(t, "Open", nil, err)
+	compare[UUID](t, "Open", uuidV4, uuid)
+
+	// Tokens signed with the old key remain valid after rotation.
+	old, err := NewSigner(oldKey)
+	if err != nil {
+		t.Fatalf("NewSigner: unexpected error: %v", err)
+	}
+	oldSU := old.Sign(uuidV4)
+	compare[byte](t, "Sign/old", 2, oldSU.KeyID)
+	compareError(t, "Verify/old", nil, s.Verify(oldSU))
+	compareError(t, "Verify/retired", ErrSignatureNotValid{KeyID: 3, Message: "unknown key ID"}, old.Verify(su))
+
+	tampered := su
+	tampered.UUID[15] ^= 0x01
+	compareError(t, "Verify/tampered-uuid", ErrSignatureNotValid{KeyID: 3, Message: "MAC does not match"}, s.Verify(tampered))
+
+	tampered = su
+	tampered.KeyID = 2
+	compareError(t, "Verify/tampered-key", ErrSignatureNotValid{KeyID: 2, Message: "MAC does not match"}, s.Verify(tampered))
+
+	tampered = su
+	tampered.MAC[0] ^= 0x80
+	_, err = s.Open(tampered.String())
+	compareError(t, "Open/tampered-mac", ErrSignatureNotValid{KeyID: 3, Message: "MAC does not match"}, err)
+
+	_, err = NewSigner(newKey, HashKey{ID: 3, Secret: oldKey.Secret})
+	compareError(t, "NewSigner/duplicate", ErrHashKeyNotValid{Algorithm: KeyedHMACSHA256, KeyID: 3, Message: "duplicate key ID"}, err)
+
+	_, err = NewSigner(HashKey{ID: 1, Secret: []byte("short")})
+	compareError(t, "NewSigner/short", ErrHashKeyNotValid{Algorithm: KeyedHMACSHA256, KeyID: 1, Message: "secret is 5 bytes; should be at least 16 bytes"}, err)
+}
+
+func TestSignedUUID_Marshal(t *testing.T) {
+	s, err := NewSigner(HashKey{ID: 3, Secret: bytes.Repeat([]byte{0x11}, 32)})
+	if err != nil {
+		t.Fatalf("NewSigner: unexpected error: %v", err)
+	}
+	su := s.Sign(uuidV4)
+
+	type wrapper struct {
+		Token SignedUUID  `json:"token"`
+		Empty *SignedUUID `json:"empty"`
+	}
+
+	data, err := json.Marshal(wrapper{Token: su})
+	compareError(t, "json.Marshal", nil, err)
+	compare[string](t, "json.Marshal", `{"token":"kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49A","empty":null}`, string(data))
+
+	var w wrapper
+	compareError(t, "json.Unmarshal", nil, json.Unmarshal(data, &w))
+	compare[SignedUUID](t, "json.Unmarshal", su, w.Token)
+
+	var scanned SignedUUID
+	value, _ := su.Value()
+	compareError(t, "Scan", nil, scanned.Scan(value))
+	compare[SignedUUID](t, "Scan", su, scanned)
+	compareError(t, "Scan/nil", nil, scanned.Scan(nil))
+	compare[bool](t, "Scan/nil", true, scanned.IsZero())
+
+	var parsed SignedUUID
+	err = parsed.UnmarshalText([]byte("kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49"))
+	compareError(t, "UnmarshalText/short", ErrParseFailed{
+		Input:   []byte("kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49"),
+		Problem: WrongInputLength,
+		Args:    mkargs(uint(43), "signed UUID", uint(44)),
+	}, err)
+	compare[string](t, "UnmarshalText/short/Error", `failed to parse "kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49" as UUID: unexpected input length 43 for signed UUID; should be 44`, err.Error())
+
+	err = parsed.UnmarshalText([]byte("kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49+"))
+	compareError(t, "UnmarshalText/char", ErrParseFailed{
+		Input:      []byte("kJJYNuEjQ_SOXmQIbEantgPeOCvZr_0_Fz61rlQCs49+"),
+		Problem:    UnexpectedCharacter,
+		Args:       mkargs(byte('+'), uint(43), "base64url digit"),
+		Index:      43,
+		ActualByte: '+',
+	}, err)
+}
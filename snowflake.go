@@ -0,0 +1,130 @@
+package youyouayedee
+
+import (
+	"fmt"
+	"time"
+)
+
+// SnowflakeLayout declares the bit layout of the time-ordered V8 UUIDs
+// produced by NewSnowflakeGenerator, in the style of Twitter's Snowflake IDs.
+//
+// Fields are placed from the most significant bit downward, in this order:
+// the timestamp, the optional tag, the worker ID, and the sequence number.
+// A field which would overlap the version or variant bits is moved past them,
+// and the skipped bits are always zero.  All bits after the sequence number
+// are random.
+//
+// To decode the generated UUIDs, register the result of the V8Layout method
+// in a V8Registry and pass that registry to UUID.Decode.
+//
+type SnowflakeLayout struct {
+	// Name identifies the layout.  If empty, then "snowflake" is used.
+	Name string
+
+	// Epoch is the time at which the timestamp is zero.  If zero, then the
+	// Unix epoch is used.
+	Epoch time.Time
+
+	// Resolution is the duration of one timestamp tick.  It must either
+	// evenly divide one second or be a whole number of seconds.  If zero,
+	// then time.Millisecond is used.
+	Resolution time.Duration
+
+	// TimestampBits is the width of the timestamp, from 1 to 48.  If zero,
+	// then 48 is used.
+	TimestampBits uint
+
+	// TagBits and Tag optionally declare a constant field, which allows
+	// several Snowflake layouts to be registered side by side.
+	TagBits uint
+	Tag     uint64
+
+	// WorkerBits is the width of the worker or shard ID.
+	WorkerBits uint
+
+	// SequenceBits is the width of the per-tick sequence number, from 1
+	// to 32.
+	SequenceBits uint
+}
+
+// V8Layout returns the equivalent V8Layout.  Its fields are named
+// "timestamp", "tag", "worker", and "sequence", plus "padN" for any bits
+// skipped to avoid the version and variant bits.
+//
+func (sl SnowflakeLayout) V8Layout() (V8Layout, error) {
+	name := sl.Name
+	if name == "" {
+		name = "snowflake"
+	}
+
+	tsBits := sl.TimestampBits
+	if tsBits == 0 {
+		tsBits = 48
+	}
+	if tsBits > 48 {
+		return V8Layout{}, ErrV8LayoutNotValid{Layout: name, Field: "timestamp", Message: fmt.Sprintf("width %d is out of range [1, 48]", tsBits)}
+	}
+	if sl.SequenceBits < 1 || sl.SequenceBits > 32 {
+		return V8Layout{}, ErrV8LayoutNotValid{Layout: name, Field: "sequence", Message: fmt.Sprintf("width %d is out of range [1, 32]", sl.SequenceBits)}
+	}
+
+	layout := V8Layout{Name: name}
+	offset := uint(0)
+	padIndex := 0
+	place := func(field V8Field) {
+		for _, reserved := range [...][2]uint{{48, 4}, {64, 2}} {
+			if offset < reserved[0]+reserved[1] && offset+field.Width > reserved[0] {
+				if offset < reserved[0] {
+					layout.Fields = append(layout.Fields, V8Field{
+						Name:   fmt.Sprintf("pad%d", padIndex),
+						Kind:   V8FieldConstant,
+						Offset: offset,
+						Width:  reserved[0] - offset,
+					})
+					padIndex++
+				}
+				offset = reserved[0] + reserved[1]
+			}
+		}
+		field.Offset = offset
+		layout.Fields = append(layout.Fields, field)
+		offset += field.Width
+	}
+
+	place(V8Field{Name: "timestamp", Kind: V8FieldTimestamp, Width: tsBits, Unit: sl.Resolution, Epoch: sl.Epoch})
+	if sl.TagBits != 0 {
+		place(V8Field{Name: "tag", Kind: V8FieldConstant, Width: sl.TagBits, Value: sl.Tag})
+	}
+	if sl.WorkerBits != 0 {
+		place(V8Field{Name: "worker", Kind: V8FieldValue, Width: sl.WorkerBits})
+	}
+	place(V8Field{Name: "sequence", Kind: V8FieldCounter, Width: sl.SequenceBits})
+
+	return NewV8Layout(layout.Name, layout.Fields...)
+}
+
+// NewSnowflakeGenerator constructs a new Generator that produces time-ordered
+// V8 UUIDs with the given Snowflake layout and worker ID.
+//
+// It is a convenience wrapper around NewV8Generator, and uses the
+// TimeSource, ClockStorage, Node, and RandomSource fields of Options in the
+// same way.  In particular, if the sequence number is exhausted within a
+// single timestamp tick, or if the clock has moved backward and the sequence
+// number is exhausted for the last tick seen, then NewUUID fails with
+// ErrSequenceOverflow instead of blocking.
+//
+func NewSnowflakeGenerator(sl SnowflakeLayout, worker uint64, o Options) (Generator, error) {
+	layout, err := sl.V8Layout()
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]uint64
+	if sl.WorkerBits != 0 {
+		values = map[string]uint64{"worker": worker}
+	} else if worker != 0 {
+		return nil, ErrV8LayoutNotValid{Layout: layout.Name, Field: "worker", Message: "layout has no worker bits"}
+	}
+
+	return NewV8Generator(layout, values, o)
+}
@@ -0,0 +1,131 @@
+package youyouayedee
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSnowflakeLayout_V8Layout(t *testing.T) {
+	sl := SnowflakeLayout{
+		Epoch:         time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		TimestampBits: 41,
+		WorkerBits:    10,
+		SequenceBits:  12,
+	}
+
+	layout, err := sl.V8Layout()
+	compareError(t, "V8Layout", nil, err)
+	compare[string](t, "Name", "snowflake", layout.Name)
+
+	type testRow struct {
+		Name   string
+		Kind   V8FieldKind
+		Offset uint
+		Width  uint
+	}
+
+	expect := [...]testRow{
+		{"timestamp", V8FieldTimestamp, 0, 41},
+		{"pad0", V8FieldConstant, 41, 7},
+		{"worker", V8FieldValue, 52, 10},
+		{"pad1", V8FieldConstant, 62, 2},
+		{"sequence", V8FieldCounter, 66, 12},
+	}
+	compare[int](t, "len(Fields)", len(expect), len(layout.Fields))
+	for index, row := range expect {
+		if index >= len(layout.Fields) {
+			break
+		}
+		field := layout.Fields[index]
+		compare[testRow](t, row.Name, row, testRow{field.Name, field.Kind, field.Offset, field.Width})
+	}
+
+	_, err = SnowflakeLayout{SequenceBits: 0}.V8Layout()
+	compareError(t, "SequenceBits=0", ErrV8LayoutNotValid{Layout: "snowflake", Field: "sequence", Message: "width 0 is out of range [1, 32]"}, err)
+
+	_, err = SnowflakeLayout{TimestampBits: 49, SequenceBits: 8}.V8Layout()
+	compareError(t, "TimestampBits=49", ErrV8LayoutNotValid{Layout: "snowflake", Field: "timestamp", Message: "width 49 is out of range [1, 48]"}, err)
+}
+
+func TestNewSnowflakeGenerator(t *testing.T) {
+	sl := SnowflakeLayout{
+		Name:          "test-snowflake",
+		Epoch:         time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		TimestampBits: 41,
+		TagBits:       4,
+		Tag:           0x5,
+		WorkerBits:    10,
+		SequenceBits:  3,
+	}
+
+	layout, err := sl.V8Layout()
+	compareError(t, "V8Layout", nil, err)
+
+	var r V8Registry
+	compareError(t, "Register", nil, r.Register(layout))
+
+	_, err = NewSnowflakeGenerator(sl, 1024, Options{})
+	compareError(t, "NewSnowflakeGenerator/worker", ErrV8LayoutNotValid{Layout: layout.Name, Field: "worker", Message: "value 1024 does not fit in 10 bits"}, err)
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cs := &testClockStorage{}
+	g, err := NewSnowflakeGenerator(sl, 42, Options{
+		TimeSource:      func() time.Time { return now },
+		ClockStorage:    cs,
+		ForceRandomNode: true,
+	})
+	compareError(t, "NewSnowflakeGenerator", nil, err)
+	if g == nil {
+		return
+	}
+
+	var prev UUID
+	for seq := 0; seq < 8; seq++ {
+		uuid, err := g.NewUUID()
+		compareError(t, "NewUUID", nil, err)
+		compare[Version](t, "Version", 8, uuid.Version())
+		compare[Variant](t, "Variant", VariantRFC4122, uuid.Variant())
+
+		compare[string](t, "UUID.Decode/opaque", "", uuid.Decode(nil).Layout)
+
+		d := uuid.Decode(nil, &r)
+		compare[string](t, "Layout", layout.Name, d.Layout)
+		compare[time.Time](t, "Time", now, d.Time.UTC())
+		compare[int](t, "Counter", seq, d.Counter)
+		compare[uint64](t, "worker", 42, d.Fields["worker"])
+		compare[uint64](t, "tag", 0x5, d.Fields["tag"])
+		compare[uint32](t, "ClockStorage", uint32(seq), cs.clock)
+
+		if seq > 0 && bytes.Compare(prev[:], uuid[:]) >= 0 {
+			t.Errorf("NewUUID: %v is not greater than %v", uuid, prev)
+		}
+		prev = uuid
+	}
+
+	_, err = g.NewUUID()
+	compareError(t, "NewUUID/overflow", ErrSequenceOverflow{Layout: layout.Name, Time: now}, err)
+
+	now = now.Add(-time.Second)
+	_, err = g.NewUUID()
+	compareError(t, "NewUUID/backward", ErrSequenceOverflow{Layout: layout.Name, Time: now.Add(time.Second)}, err)
+
+	now = now.Add(time.Second + time.Millisecond)
+	uuid, err := g.NewUUID()
+	compareError(t, "NewUUID/next", nil, err)
+	d := uuid.Decode(nil, &r)
+	compare[time.Time](t, "Time", now, d.Time.UTC())
+	compare[int](t, "Counter", 0, d.Counter)
+
+	// A restarted generator picks up where the last one left off.
+	g2, err := NewSnowflakeGenerator(sl, 42, Options{
+		TimeSource:      func() time.Time { return now },
+		ClockStorage:    cs,
+		ForceRandomNode: true,
+	})
+	compareError(t, "NewSnowflakeGenerator/restart", nil, err)
+	uuid, err = g2.NewUUID()
+	compareError(t, "NewUUID/restart", nil, err)
+	d = uuid.Decode(nil, &r)
+	compare[int](t, "Counter", 1, d.Counter)
+}
@@ -4,6 +4,7 @@
 package youyouayedee
 
 import (
+	"net"
 	"os"
 )
 
@@ -16,6 +17,6 @@ func lockFile(file *os.File) error {
 	}
 }
 
-func listHardwareAddresses() ([]Node, error) {
+func listInterfaces() ([]net.Interface, error) {
 	return nil, nil
 }
@@ -0,0 +1,100 @@
+package youyouayedee
+
+import (
+	"time"
+)
+
+// TAIMinusUTC returns the offset between International Atomic Time (TAI) and
+// Coordinated Universal Time (UTC) at the given time, according to the given
+// LeapSecondCalculator.
+//
+// If lsc is nil, then a LeapSecondCalculatorFixed is used.  If lsc implements
+// FractionalLeapSecondCalculator, then the result may include a fraction of a
+// second, e.g. during a leap smear.
+//
+// Results for times before 1972 are not meaningful, as TAI-UTC was not an
+// integral number of seconds at that time.
+//
+func TAIMinusUTC(t time.Time, lsc LeapSecondCalculator) time.Duration {
+	s, ns := t.Unix(), int64(t.Nanosecond())
+	siS, siNS := unixTimeToSITime(defaultLSC(lsc), s, ns)
+	offset := (siS-s)*nanosPerSecond + (siNS - ns)
+	return time.Duration(offset) + taiMinusUTCAtZeroLeapSeconds*time.Second
+}
+
+// TimeToTAI converts the given time to seconds and nanoseconds of
+// International Atomic Time (TAI) since 1970-01-01T00:00:00 TAI, using the
+// given LeapSecondCalculator.  This matches the convention of the Linux
+// CLOCK_TAI clock.
+//
+// If lsc is nil, then a LeapSecondCalculatorFixed is used.
+//
+func TimeToTAI(t time.Time, lsc LeapSecondCalculator) (int64, int) {
+	s, ns := unixTimeToSITime(defaultLSC(lsc), t.Unix(), int64(t.Nanosecond()))
+	return s + taiMinusUTCAtZeroLeapSeconds, int(ns)
+}
+
+// TimeFromTAI is the inverse of TimeToTAI.
+//
+// A positive leap second has no representation in Unix time, so the TAI
+// seconds which fall within a leap second are mapped onto the preceding
+// Unix second.
+//
+func TimeFromTAI(seconds int64, nanos int, lsc LeapSecondCalculator) time.Time {
+	s, ns := siTimeToUnixTime(defaultLSC(lsc), seconds-taiMinusUTCAtZeroLeapSeconds, int64(nanos))
+	return time.Unix(s, ns)
+}
+
+// TimeToGPS converts the given time to seconds and nanoseconds of GPS time
+// since the GPS epoch at 1980-01-06T00:00:00Z, using the given
+// LeapSecondCalculator.  GPS time does not observe leap seconds, and always
+// runs exactly 19 seconds behind TAI.
+//
+// If lsc is nil, then a LeapSecondCalculatorFixed is used.
+//
+func TimeToGPS(t time.Time, lsc LeapSecondCalculator) (int64, int) {
+	s, ns := TimeToTAI(t, lsc)
+	return s - taiMinusGPS - gpsEpochUnixSeconds, ns
+}
+
+// TimeFromGPS is the inverse of TimeToGPS.
+func TimeFromGPS(seconds int64, nanos int, lsc LeapSecondCalculator) time.Time {
+	return TimeFromTAI(seconds+taiMinusGPS+gpsEpochUnixSeconds, nanos, lsc)
+}
+
+func defaultLSC(lsc LeapSecondCalculator) LeapSecondCalculator {
+	if lsc == nil {
+		return LeapSecondCalculatorFixed{}
+	}
+	return lsc
+}
+
+// unixTimeToSITime converts Unix time to the number of SI seconds since
+// 1970-01-01T00:00:00Z, as counted by lsc.
+func unixTimeToSITime(lsc LeapSecondCalculator, s int64, ns int64) (int64, int64) {
+	if flsc, ok := lsc.(FractionalLeapSecondCalculator); ok {
+		offset := int64(flsc.LeapSecondOffsetSinceUnixEpoch(s, int(ns), false))
+		return normalizeSecondsAndNanos(s+offset/nanosPerSecond, ns+offset%nanosPerSecond)
+	}
+	return s + int64(lsc.LeapSecondsSinceUnixEpoch(s, false)), ns
+}
+
+// siTimeToUnixTime is the inverse of unixTimeToSITime.
+func siTimeToUnixTime(lsc LeapSecondCalculator, s int64, ns int64) (int64, int64) {
+	if flsc, ok := lsc.(FractionalLeapSecondCalculator); ok {
+		offset := int64(flsc.LeapSecondOffsetSinceUnixEpoch(s, int(ns), true))
+		return normalizeSecondsAndNanos(s-offset/nanosPerSecond, ns-offset%nanosPerSecond)
+	}
+	return s - int64(lsc.LeapSecondsSinceUnixEpoch(s, true)), ns
+}
+
+func normalizeSecondsAndNanos(s int64, ns int64) (int64, int64) {
+	if ns < 0 {
+		ns += nanosPerSecond
+		s--
+	} else if ns >= nanosPerSecond {
+		ns -= nanosPerSecond
+		s++
+	}
+	return s, ns
+}
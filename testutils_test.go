@@ -3,6 +3,7 @@ package youyouayedee
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 // UTC time:
@@ -86,6 +87,30 @@ var (
 	lscFixed LeapSecondCalculator = LeapSecondCalculatorFixed{}
 )
 
+// testClockStorage is an in-memory ClockStorage which reports
+// ErrClockNotFound until the first call to Store.
+type testClockStorage struct {
+	last  time.Time
+	clock uint32
+	saved bool
+}
+
+func (cs *testClockStorage) Load(Node) (time.Time, uint32, error) {
+	if !cs.saved {
+		return time.Time{}, 0, ErrClockNotFound{}
+	}
+	return cs.last, cs.clock, nil
+}
+
+func (cs *testClockStorage) Store(_ Node, last time.Time, clock uint32) error {
+	cs.last = last
+	cs.clock = clock
+	cs.saved = true
+	return nil
+}
+
+var _ ClockStorage = (*testClockStorage)(nil)
+
 func compare[V comparable](t *testing.T, name string, expect V, actual V) {
 	t.Helper()
 
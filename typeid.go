@@ -0,0 +1,408 @@
+package youyouayedee
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// TypeIDMaxPrefixLength is the maximum length of a TypeID prefix.
+const TypeIDMaxPrefixLength = 63
+
+const typeIDEncode = "0123456789abcdefghjkmnpqrstvwxyz"
+
+var typeIDDecode = makeDecodeTable(typeIDEncode)
+
+// TypeID is a type-safe, K-sortable identifier, as described by the TypeID
+// specification at <https://github.com/jetify-com/typeid>.  It pairs a short
+// lower case type prefix with a UUID, e.g. "user_01h455vb4pex5vsknk084sn02q".
+//
+// The text form is the prefix, an underscore, and the UUID's 128 bits as 26
+// characters of lower case Crockford base32.  If the prefix is empty, then the
+// underscore is omitted.
+//
+// The prefix must be at most 63 characters long, must consist only of the
+// lower case ASCII letters "a" through "z" and the underscore "_", and must
+// not begin or end with an underscore.  See ValidateTypeIDPrefix.
+//
+type TypeID struct {
+	Prefix string
+	UUID   UUID
+}
+
+// NewTypeID generates a new TypeID with the given prefix, using the given
+// Generator to produce the UUID.
+//
+// If g is nil, then a shared V7 generator from NewTimeGenerator is used.
+//
+func NewTypeID(prefix string, g Generator) (TypeID, error) {
+	if err := ValidateTypeIDPrefix(prefix); err != nil {
+		return TypeID{}, err
+	}
+
+	uuid, err := newTypeIDUUID(g)
+	if err != nil {
+		return TypeID{}, err
+	}
+
+	return TypeID{Prefix: prefix, UUID: uuid}, nil
+}
+
+// ParseTypeID parses a TypeID in its text form.
+//
+// Unlike ParseULID, decoding of the base32 suffix is strict: only lower case
+// digits are accepted, and Crockford's aliases for "0" and "1" are rejected.
+//
+func ParseTypeID(str string) (TypeID, error) {
+	var tmp [96]byte
+	input := append(tmp[:0], str...)
+	return parseTypeID(input)
+}
+
+// ValidateTypeIDPrefix checks that the given string is a valid TypeID prefix.
+// The empty string is valid.  The returned error, if any, is an
+// ErrParseFailed with the InvalidTypeIDPrefix problem.
+//
+func ValidateTypeIDPrefix(prefix string) error {
+	var tmp [96]byte
+	input := append(tmp[:0], prefix...)
+	return checkTypeIDPrefix(input, input)
+}
+
+// String returns the TypeID in its text form.
+func (id TypeID) String() string {
+	return string(id.AppendTo(make([]byte, 0, len(id.Prefix)+1+ULIDLength)))
+}
+
+// AppendTo appends the TypeID's text form to the given []byte.
+func (id TypeID) AppendTo(out []byte) []byte {
+	if id.Prefix != "" {
+		out = append(out, id.Prefix...)
+		out = append(out, '_')
+	}
+	return appendBase32(out, id.UUID, typeIDEncode)
+}
+
+// IsZero returns true iff the TypeID has an empty prefix and a Nil UUID.
+func (id TypeID) IsZero() bool {
+	return id.Prefix == "" && id.UUID.IsZero()
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (id TypeID) MarshalText() ([]byte, error) {
+	return id.AppendTo(nil), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (id TypeID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (id *TypeID) UnmarshalText(text []byte) error {
+	var err error
+	*id, err = parseTypeID(text)
+	return err
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (id *TypeID) UnmarshalJSON(data []byte) error {
+	*id = TypeID{}
+
+	if len(data) == 4 && string(data) == "null" {
+		return nil
+	}
+
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		*id, err = ParseTypeID(str)
+	}
+	return err
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (id *TypeID) Scan(value interface{}) error {
+	var err error
+	*id = TypeID{}
+	switch x := value.(type) {
+	case nil:
+		err = nil
+
+	case string:
+		*id, err = ParseTypeID(x)
+
+	case []byte:
+		*id, err = parseTypeID(x)
+
+	default:
+		err = fmt.Errorf("don't know how to interpret a value of type %T as a TypeID", value)
+	}
+	return err
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (id TypeID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// TypeIDPrefix is implemented by types which name a TypeID prefix at compile
+// time, for use with TypedID.  Implementations are typically empty structs:
+//
+//	type UserPrefix struct{}
+//
+//	func (UserPrefix) TypeIDPrefix() string { return "user" }
+//
+//	type UserID = youyouayedee.TypedID[UserPrefix]
+//
+type TypeIDPrefix interface {
+	TypeIDPrefix() string
+}
+
+// TypedID is a TypeID whose prefix is fixed at compile time by the type
+// parameter P.  Parsing or unmarshaling a TypedID fails with
+// WrongTypeIDPrefix if the input's prefix does not match.
+//
+type TypedID[P TypeIDPrefix] struct {
+	UUID UUID
+}
+
+// NewTypedID generates a new TypedID, using the given Generator to produce the
+// UUID.  If g is nil, then the same default is used as by NewTypeID.
+//
+func NewTypedID[P TypeIDPrefix](g Generator) (TypedID[P], error) {
+	var zero TypedID[P]
+
+	if err := ValidateTypeIDPrefix(zero.Prefix()); err != nil {
+		return zero, err
+	}
+
+	uuid, err := newTypeIDUUID(g)
+	if err != nil {
+		return zero, err
+	}
+
+	return TypedID[P]{UUID: uuid}, nil
+}
+
+// ParseTypedID parses a TypedID in its text form.
+func ParseTypedID[P TypeIDPrefix](str string) (TypedID[P], error) {
+	var tmp [96]byte
+	input := append(tmp[:0], str...)
+	return parseTypedID[P](input)
+}
+
+// TypedIDFromTypeID converts a TypeID to a TypedID, checking that its prefix
+// matches.
+//
+func TypedIDFromTypeID[P TypeIDPrefix](id TypeID) (TypedID[P], error) {
+	var zero TypedID[P]
+	expect := zero.Prefix()
+	if id.Prefix != expect {
+		return zero, ErrParseFailed{
+			Input:   id.AppendTo(nil),
+			Problem: WrongTypeIDPrefix,
+			Args:    mkargs(id.Prefix, expect),
+		}
+	}
+	return TypedID[P]{UUID: id.UUID}, nil
+}
+
+// Prefix returns the prefix named by P.
+func (id TypedID[P]) Prefix() string {
+	var p P
+	return p.TypeIDPrefix()
+}
+
+// TypeID returns the equivalent untyped TypeID.
+func (id TypedID[P]) TypeID() TypeID {
+	return TypeID{Prefix: id.Prefix(), UUID: id.UUID}
+}
+
+// String returns the TypedID in its text form.
+func (id TypedID[P]) String() string {
+	return id.TypeID().String()
+}
+
+// AppendTo appends the TypedID's text form to the given []byte.
+func (id TypedID[P]) AppendTo(out []byte) []byte {
+	return id.TypeID().AppendTo(out)
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (id TypedID[P]) MarshalText() ([]byte, error) {
+	return id.AppendTo(nil), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (id TypedID[P]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (id *TypedID[P]) UnmarshalText(text []byte) error {
+	var err error
+	*id, err = parseTypedID[P](text)
+	return err
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (id *TypedID[P]) UnmarshalJSON(data []byte) error {
+	*id = TypedID[P]{}
+
+	if len(data) == 4 && string(data) == "null" {
+		return nil
+	}
+
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err == nil {
+		*id, err = ParseTypedID[P](str)
+	}
+	return err
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (id *TypedID[P]) Scan(value interface{}) error {
+	var tmp TypeID
+	err := tmp.Scan(value)
+	if err == nil && value != nil {
+		*id, err = TypedIDFromTypeID[P](tmp)
+		return err
+	}
+	*id = TypedID[P]{}
+	return err
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (id TypedID[P]) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+var (
+	typeIDGenOnce sync.Once
+	typeIDGen     Generator
+	typeIDGenErr  error
+)
+
+func newTypeIDUUID(g Generator) (UUID, error) {
+	if g == nil {
+		typeIDGenOnce.Do(func() {
+			typeIDGen, typeIDGenErr = NewTimeGenerator(7, Options{ForceRandomNode: true})
+		})
+		if typeIDGenErr != nil {
+			return Nil, typeIDGenErr
+		}
+		g = typeIDGen
+	}
+	return g.NewUUID()
+}
+
+func parseTypeID(input []byte) (TypeID, error) {
+	sep := -1
+	for ii := len(input) - 1; ii >= 0; ii-- {
+		if input[ii] == '_' {
+			sep = ii
+			break
+		}
+	}
+
+	prefix := input[:0]
+	suffix := input
+	if sep >= 0 {
+		prefix = input[:sep]
+		suffix = input[sep+1:]
+		if len(prefix) == 0 {
+			return TypeID{}, ErrParseFailed{
+				Input:   input,
+				Problem: InvalidTypeIDPrefix,
+				Args:    mkargs("", "must not be empty when followed by a separator"),
+			}
+		}
+	}
+
+	if err := checkTypeIDPrefix(input, prefix); err != nil {
+		return TypeID{}, err
+	}
+
+	if suffixLen := uint(len(suffix)); suffixLen != ULIDLength {
+		return TypeID{}, ErrParseFailed{
+			Input:   input,
+			Problem: WrongTypeIDSuffixLength,
+			Args:    mkargs(suffixLen, string(suffix), string(prefix), uint(ULIDLength)),
+		}
+	}
+
+	uuid, err := parseBase32(suffix, &typeIDDecode, "TypeID suffix", "lower case base32 digit")
+	if err != nil {
+		var pf ErrParseFailed
+		if !errors.As(err, &pf) {
+			return TypeID{}, err
+		}
+		pf.Input = input
+		if pf.Problem == UnexpectedCharacter {
+			pf.Index += uint(len(input) - len(suffix))
+			pf.Args[1] = pf.Index
+		}
+		return TypeID{}, pf
+	}
+
+	return TypeID{Prefix: string(prefix), UUID: uuid}, nil
+}
+
+func parseTypedID[P TypeIDPrefix](input []byte) (TypedID[P], error) {
+	id, err := parseTypeID(input)
+	if err != nil {
+		return TypedID[P]{}, err
+	}
+	return TypedIDFromTypeID[P](id)
+}
+
+func checkTypeIDPrefix(input []byte, prefix []byte) error {
+	prefixLen := len(prefix)
+	if prefixLen == 0 {
+		return nil
+	}
+
+	var reason string
+	switch {
+	case prefixLen > TypeIDMaxPrefixLength:
+		reason = fmt.Sprintf("must be at most %d characters long", TypeIDMaxPrefixLength)
+	case prefix[0] == '_' || prefix[prefixLen-1] == '_':
+		reason = "must not begin or end with an underscore"
+	default:
+		for ii := 0; ii < prefixLen; ii++ {
+			ch := prefix[ii]
+			if (ch < 'a' || ch > 'z') && ch != '_' {
+				return ErrParseFailed{
+					Input:      input,
+					Problem:    UnexpectedCharacter,
+					Args:       mkargs(ch, uint(ii), "lower case letter or underscore"),
+					Index:      uint(ii),
+					ActualByte: ch,
+				}
+			}
+		}
+		return nil
+	}
+
+	return ErrParseFailed{
+		Input:   input,
+		Problem: InvalidTypeIDPrefix,
+		Args:    mkargs(string(prefix), reason),
+	}
+}
+
+var (
+	_ encoding.TextMarshaler   = TypeID{}
+	_ json.Marshaler           = TypeID{}
+	_ driver.Valuer            = TypeID{}
+	_ fmt.Stringer             = TypeID{}
+	_ encoding.TextUnmarshaler = (*TypeID)(nil)
+	_ json.Unmarshaler         = (*TypeID)(nil)
+	_ sql.Scanner              = (*TypeID)(nil)
+)
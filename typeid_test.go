@@ -0,0 +1,146 @@
+package youyouayedee
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testUserPrefix struct{}
+
+func (testUserPrefix) TypeIDPrefix() string { return "user" }
+
+type testUserID = TypedID[testUserPrefix]
+
+func TestTypeID(t *testing.T) {
+	type testRow struct {
+		Input  string
+		Prefix string
+		UUID   string
+	}
+
+	testData := [...]testRow{
+		{Input: "00000000000000000000000000", Prefix: "", UUID: "00000000-0000-0000-0000-000000000000"},
+		{Input: "7zzzzzzzzzzzzzzzzzzzzzzzzz", Prefix: "", UUID: "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		{Input: "prefix_01h455vb4pex5vsknk084sn02q", Prefix: "prefix", UUID: "01890a5d-ac96-774b-bcce-b302099a8057"},
+		{Input: "pre_fix_00000000000000000000000000", Prefix: "pre_fix", UUID: "00000000-0000-0000-0000-000000000000"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Input, func(t *testing.T) {
+			id, err := ParseTypeID(row.Input)
+			compareError(t, "ParseTypeID", nil, err)
+			compare[string](t, "Prefix", row.Prefix, id.Prefix)
+			compare[string](t, "UUID", row.UUID, id.UUID.String())
+			compare[string](t, "String", row.Input, id.String())
+		})
+	}
+
+	invalid := [...]string{
+		"PREFIX_00000000000000000000000000",
+		"_00000000000000000000000000",
+		"prefix__00000000000000000000000000",
+		"_prefix_00000000000000000000000000",
+		"pre.fix_00000000000000000000000000",
+		"prefix_0000000000000000000000000",
+		"prefix_0000000000000000000000000o",
+		"prefix_0000000000000000000000000U",
+		"prefix_8zzzzzzzzzzzzzzzzzzzzzzzzz",
+		"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijkl_00000000000000000000000000",
+	}
+
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseTypeID(input)
+			if _, ok := err.(ErrParseFailed); !ok {
+				t.Errorf("ParseTypeID: expected ErrParseFailed, got %#v", err)
+			}
+		})
+	}
+
+	_, err := ParseTypeID("pre.fix_00000000000000000000000000")
+	compareError(t, "ParseTypeID/badchar", ErrParseFailed{
+		Input:      []byte("pre.fix_00000000000000000000000000"),
+		Problem:    UnexpectedCharacter,
+		Args:       mkargs(byte('.'), uint(3), "lower case letter or underscore"),
+		Index:      3,
+		ActualByte: '.',
+	}, err)
+
+	_, err = ParseTypeID("prefix_0000000000000000000000000U")
+	compareError(t, "ParseTypeID/badsuffix", ErrParseFailed{
+		Input:      []byte("prefix_0000000000000000000000000U"),
+		Problem:    UnexpectedCharacter,
+		Args:       mkargs(byte('U'), uint(32), "lower case base32 digit"),
+		Index:      32,
+		ActualByte: 'U',
+	}, err)
+
+	_, err = ParseTypeID("prefix_0000000000000000000000000")
+	compareError(t, "ParseTypeID/shortsuffix", ErrParseFailed{
+		Input:   []byte("prefix_0000000000000000000000000"),
+		Problem: WrongTypeIDSuffixLength,
+		Args:    mkargs(uint(25), "0000000000000000000000000", "prefix", uint(26)),
+	}, err)
+	compare[string](t, "ParseTypeID/shortsuffix/Error", `failed to parse "prefix_0000000000000000000000000" as UUID: unexpected length 25 for TypeID suffix "0000000000000000000000000" after prefix "prefix"; should be 26`, err.Error())
+
+	id, err := NewTypeID("user", nil)
+	compareError(t, "NewTypeID", nil, err)
+	compare[string](t, "Prefix", "user", id.Prefix)
+	compare[Version](t, "Version", 7, id.UUID.Version())
+
+	_, err = NewTypeID("User", nil)
+	compare[bool](t, "NewTypeID/invalid", true, err != nil)
+
+	data, err := json.Marshal(id)
+	compareError(t, "MarshalJSON", nil, err)
+	var id2 TypeID
+	err = json.Unmarshal(data, &id2)
+	compareError(t, "UnmarshalJSON", nil, err)
+	compare[TypeID](t, "UnmarshalJSON", id, id2)
+
+	err = id2.Scan(id.String())
+	compareError(t, "Scan", nil, err)
+	compare[TypeID](t, "Scan", id, id2)
+}
+
+func TestTypedID(t *testing.T) {
+	id, err := NewTypedID[testUserPrefix](nil)
+	compareError(t, "NewTypedID", nil, err)
+	compare[string](t, "Prefix", "user", id.Prefix())
+
+	parsed, err := ParseTypedID[testUserPrefix](id.String())
+	compareError(t, "ParseTypedID", nil, err)
+	compare[testUserID](t, "ParseTypedID", id, parsed)
+
+	_, err = ParseTypedID[testUserPrefix]("team_01h455vb4pex5vsknk084sn02q")
+	compareError(t, "ParseTypedID/wrong", ErrParseFailed{
+		Input:   []byte("team_01h455vb4pex5vsknk084sn02q"),
+		Problem: WrongTypeIDPrefix,
+		Args:    mkargs("team", "user"),
+	}, err)
+
+	type record struct {
+		ID testUserID `json:"id"`
+	}
+
+	data, err := json.Marshal(record{ID: id})
+	compareError(t, "MarshalJSON", nil, err)
+	compare[string](t, "MarshalJSON", `{"id":"`+id.String()+`"}`, string(data))
+
+	var rec record
+	err = json.Unmarshal(data, &rec)
+	compareError(t, "UnmarshalJSON", nil, err)
+	compare[testUserID](t, "UnmarshalJSON", id, rec.ID)
+
+	err = json.Unmarshal([]byte(`{"id":"team_01h455vb4pex5vsknk084sn02q"}`), &rec)
+	compare[bool](t, "UnmarshalJSON/wrong", true, err != nil)
+
+	var scanned testUserID
+	err = scanned.Scan([]byte(id.String()))
+	compareError(t, "Scan", nil, err)
+	compare[testUserID](t, "Scan", id, scanned)
+
+	value, err := id.Value()
+	compareError(t, "Value", nil, err)
+	compare[string](t, "Value", id.String(), value.(string))
+}
@@ -0,0 +1,141 @@
+package youyouayedee
+
+// ULIDLength is the length of a ULID in its Crockford base32 text form.
+const ULIDLength = 26
+
+const crockfordEncode = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var crockfordDecode = makeCrockfordDecode()
+
+// ULID formats the UUID's 128 bits as a ULID, i.e. as 26 characters of
+// Crockford base32.  No conversion is performed; see ULIDToV7 for that.
+func (uuid UUID) ULID() string {
+	var tmp [32]byte
+	return string(uuid.AppendULID(tmp[:0]))
+}
+
+// AppendULID appends the UUID's 128 bits to the given []byte as a ULID.
+func (uuid UUID) AppendULID(out []byte) []byte {
+	return appendBase32(out, uuid, crockfordEncode)
+}
+
+// ParseULID parses a ULID in its 26-character Crockford base32 text form.
+//
+// The result holds exactly the 128 bits of the ULID, so it will usually not
+// be an RFC 4122 variant UUID; see ULIDToV7.  Decoding is case insensitive,
+// and the letters "I", "L", and "O" are accepted as aliases for "1", "1", and
+// "0", as specified by Crockford.
+//
+func ParseULID(str string) (UUID, error) {
+	var tmp [64]byte
+	input := append(tmp[:0], str...)
+	return parseULID(input)
+}
+
+// ULIDToV7 converts a ULID into a valid V7 UUID with the same 48-bit
+// millisecond timestamp.
+//
+// ULIDs and V7 UUIDs share the same timestamp layout, so this only needs to
+// overwrite 6 of the ULID's 80 random bits with the version and variant.  The
+// conversion is therefore lossy, but the result's timestamp can be recovered
+// with Decode.
+//
+func ULIDToV7(ulid UUID) UUID {
+	ulid[6] = (ulid[6] & 0x0f) | 0x70
+	ulid[8] = (ulid[8] & 0x3f) | 0x80
+	return ulid
+}
+
+func parseULID(input []byte) (UUID, error) {
+	return parseBase32(input, &crockfordDecode, "ULID", "Crockford base32 digit")
+}
+
+// appendBase32 appends the UUID's 128 bits as 26 base32 digits, using the
+// given 32-character alphabet.
+func appendBase32(out []byte, uuid UUID, alphabet string) []byte {
+	hi, lo := uuid.uint128()
+
+	// 26 characters * 5 bits = 130 bits, so the first character only
+	// holds the top 3 bits of the value.
+	for ci := uint(0); ci < ULIDLength; ci++ {
+		shift := 125 - 5*ci
+		var value uint64
+		if shift >= 64 {
+			value = hi >> (shift - 64)
+		} else {
+			value = (lo >> shift) | (hi << (64 - shift))
+		}
+		out = append(out, alphabet[value&0x1f])
+	}
+	return out
+}
+
+// parseBase32 is the inverse of appendBase32.  Bytes which map to 0xff in the
+// decoding table are rejected, and are described as "expect" in the error.
+// Input of the wrong length is described as "kind" in the error.
+func parseBase32(input []byte, table *[256]byte, kind string, expect string) (UUID, error) {
+	inputLen := uint(len(input))
+	if inputLen != ULIDLength {
+		return Nil, ErrParseFailed{
+			Input:   input,
+			Problem: WrongInputLength,
+			Args:    mkargs(inputLen, kind, uint(ULIDLength)),
+		}
+	}
+
+	var hi, lo uint64
+	for ii := uint(0); ii < inputLen; ii++ {
+		ch := input[ii]
+		value := table[ch]
+		if value >= 0x20 {
+			return Nil, ErrParseFailed{
+				Input:      input,
+				Problem:    UnexpectedCharacter,
+				Args:       mkargs(ch, ii, expect),
+				Index:      ii,
+				ActualByte: ch,
+			}
+		}
+		if ii == 0 && value >= 0x08 {
+			return Nil, ErrParseFailed{
+				Input:   input,
+				Problem: ValueOverflow,
+				Args:    mkargs("base32"),
+			}
+		}
+		hi = (hi << 5) | (lo >> 59)
+		lo = (lo << 5) | uint64(value)
+	}
+
+	var uuid UUID
+	uuid.putUint128(hi, lo)
+	return uuid, nil
+}
+
+func makeDecodeTable(alphabet string) [256]byte {
+	var table [256]byte
+	for index := range table {
+		table[index] = 0xff
+	}
+	for index := 0; index < len(alphabet); index++ {
+		table[alphabet[index]] = byte(index)
+	}
+	return table
+}
+
+func makeCrockfordDecode() [256]byte {
+	table := makeDecodeTable(crockfordEncode)
+	for index := 0; index < len(crockfordEncode); index++ {
+		ch := crockfordEncode[index]
+		if ch >= 'A' && ch <= 'Z' {
+			table[ch+('a'-'A')] = byte(index)
+		}
+	}
+	for _, ch := range []byte("iIlL") {
+		table[ch] = 1
+	}
+	for _, ch := range []byte("oO") {
+		table[ch] = 0
+	}
+	return table
+}
@@ -13,6 +13,8 @@ const upperCase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
 const hexEncode = "0123456789abcdef"
 
+const hexEncodeUpper = "0123456789ABCDEF"
+
 var hexDecode = [256]byte{
 	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // 0x00 .. 0x07
 	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // 0x08 .. 0x0f
@@ -83,56 +85,83 @@ func readRandom(rng io.Reader, out []byte) error {
 
 func isErrClockNotFound(err error) bool {
 	var unavailable ErrClockNotFound
-	return errors.Is(err, &unavailable)
+	return errors.As(err, &unavailable)
 }
 
 func parse(input []byte, isBytes bool) (UUID, error) {
+	return ParseOptions{}.parse(input, isBytes)
+}
+
+func (opts ParseOptions) parse(input []byte, isBytes bool) (UUID, error) {
 	var output UUID
 	var requiredByteIndices []uint
 	var requiredByteValues []byte
 	var requiredByteCount uint
 	var a, b, c, d, e uint
+	var format Format
 	var okToParse, allZeroes, allOnes bool
 
 	inputLen := uint(len(input))
 
-	if bytes.ContainsAny(input, upperCase) {
-		dupe := make([]byte, inputLen)
-		copy(dupe, input)
-		input = bytes.ToLower(dupe)
+	if isBytes && inputLen == Size {
+		if !opts.allowsFormat(FormatBinary) {
+			return Nil, ErrParseFailed{
+				Input:   input,
+				Problem: DisallowedFormat,
+				Args:    mkargs(FormatBinary),
+			}
+		}
+		copy(output[:], input)
+		allZeroes = true
+		allOnes = true
+		for oi := uint(0); oi < Size; oi++ {
+			allZeroes = allZeroes && (output[oi] == 0x00)
+			allOnes = allOnes && (output[oi] == 0xff)
+		}
+		return opts.checkParse(input, output, allZeroes, allOnes)
+	}
+
+	if inputLen == ULIDLength && opts.listsFormat(FormatULID) {
+		output, err := parseULID(input)
+		if err != nil {
+			return Nil, err
+		}
+		return opts.checkParse(input, output, output.IsZero(), output.IsMax())
+	}
+
+	if ii := bytes.IndexAny(input, upperCase); ii >= 0 {
+		if opts.RequireLowerCase {
+			return Nil, ErrParseFailed{
+				Input:      input,
+				Problem:    UnexpectedCharacter,
+				Args:       mkargs(input[ii], uint(ii), "lower case character"),
+				Index:      uint(ii),
+				ActualByte: input[ii],
+			}
+		}
+		input = asciiToLower(input)
 	}
 
 	switch inputLen {
 	case 0:
-		return Nil, nil
+		return opts.checkKeyword(input, Nil)
 
 	case 3:
 		if string(input) == "nil" {
-			return Nil, nil
+			return opts.checkKeyword(input, Nil)
 		}
 		if string(input) == "max" {
-			return Max, nil
+			return opts.checkKeyword(input, Max)
 		}
 
 	case 4:
 		if string(input) == "null" {
-			return Nil, nil
-		}
-
-	case 16:
-		if isBytes {
-			copy(output[:], input)
-			allZeroes = true
-			allOnes = true
-			for oi := uint(0); oi < Size; oi++ {
-				allZeroes = allZeroes && (output[oi] == 0x00)
-				allOnes = allOnes && (output[oi] == 0xff)
-			}
-			return checkParse(input, output, allZeroes, allOnes)
+			return opts.checkKeyword(input, Nil)
 		}
 
 	case 32:
 		a, b, c, d, e = 0, 8, 12, 16, 20
+		format = FormatHex
 		okToParse = true
 
 	case 36:
@@ -140,6 +169,7 @@ func parse(input []byte, isBytes bool) (UUID, error) {
 		requiredByteValues = []byte{'-', '-', '-', '-'}
 		requiredByteCount = 4
 		a, b, c, d, e = 0, 9, 14, 19, 24
+		format = FormatCanonical
 		okToParse = true
 
 	case 38:
@@ -147,6 +177,7 @@ func parse(input []byte, isBytes bool) (UUID, error) {
 		requiredByteValues = []byte{'{', '-', '-', '-', '-', '}'}
 		requiredByteCount = 6
 		a, b, c, d, e = 1, 10, 15, 20, 25
+		format = FormatBraced
 		okToParse = true
 
 	case 45:
@@ -154,6 +185,7 @@ func parse(input []byte, isBytes bool) (UUID, error) {
 		requiredByteValues = []byte{'u', 'r', 'n', ':', 'u', 'u', 'i', 'd', ':', '-', '-', '-', '-'}
 		requiredByteCount = 13
 		a, b, c, d, e = 9, 18, 23, 28, 33
+		format = FormatURN
 		okToParse = true
 	}
 
@@ -169,6 +201,14 @@ func parse(input []byte, isBytes bool) (UUID, error) {
 		}
 	}
 
+	if !opts.allowsFormat(format) {
+		return Nil, ErrParseFailed{
+			Input:   input,
+			Problem: DisallowedFormat,
+			Args:    mkargs(format),
+		}
+	}
+
 	for xi := uint(0); xi < requiredByteCount; xi++ {
 		ii := requiredByteIndices[xi]
 		ch := requiredByteValues[xi]
@@ -216,29 +256,116 @@ func parse(input []byte, isBytes bool) (UUID, error) {
 		allOnes = allOnes && (output[oi] == 0xff)
 	}
 
-	return checkParse(input, output, allZeroes, allOnes)
+	return opts.checkParse(input, output, allZeroes, allOnes)
 }
 
-func checkParse(input []byte, output UUID, allZeroes bool, allOnes bool) (UUID, error) {
+func (opts ParseOptions) checkParse(input []byte, output UUID, allZeroes bool, allOnes bool) (UUID, error) {
 	actualVB := output[8]
 	expectVB := (actualVB & 0x3f) | 0x80
-	if actualVB == expectVB || allZeroes || allOnes {
-		return output, nil
+	if actualVB != expectVB && !allZeroes && !allOnes && !opts.AcceptAnyVariant {
+		return Nil, ErrParseFailed{
+			Input:      input,
+			Problem:    WrongVariant,
+			Args:       mkargs(actualVB, expectVB),
+			ExpectByte: expectVB,
+			ActualByte: actualVB,
+		}
 	}
 
-	return Nil, ErrParseFailed{
-		Input:      input,
-		Problem:    WrongVariant,
-		Args:       mkargs(actualVB, expectVB),
-		ExpectByte: expectVB,
-		ActualByte: actualVB,
+	if allZeroes || allOnes {
+		return opts.checkNilOrMax(input, output)
 	}
+
+	if len(opts.Versions) != 0 {
+		version := output.Version()
+		found := false
+		if output.Variant() == VariantRFC4122 {
+			for _, v := range opts.Versions {
+				if v == version {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return Nil, ErrParseFailed{
+				Input:   input,
+				Problem: WrongVersion,
+				Args:    mkargs(byte(version), opts.Versions),
+			}
+		}
+	}
+
+	return output, nil
+}
+
+func (opts ParseOptions) checkKeyword(input []byte, output UUID) (UUID, error) {
+	if opts.RejectKeywords {
+		return Nil, ErrParseFailed{
+			Input:   input,
+			Problem: DisallowedKeyword,
+			Args:    mkargs(string(input)),
+		}
+	}
+	return opts.checkNilOrMax(input, output)
+}
+
+func (opts ParseOptions) checkNilOrMax(input []byte, output UUID) (UUID, error) {
+	if opts.RejectNilAndMax {
+		name := "nil"
+		if output.IsMax() {
+			name = "max"
+		}
+		return Nil, ErrParseFailed{
+			Input:   input,
+			Problem: DisallowedValue,
+			Args:    mkargs(name),
+		}
+	}
+	return output, nil
+}
+
+func (opts ParseOptions) allowsFormat(format Format) bool {
+	return len(opts.Formats) == 0 || opts.listsFormat(format)
+}
+
+func (opts ParseOptions) listsFormat(format Format) bool {
+	for _, allowed := range opts.Formats {
+		if allowed == format {
+			return true
+		}
+	}
+	return false
+}
+
+func asciiToLower(input []byte) []byte {
+	inputLen := uint(len(input))
+	dupe := make([]byte, inputLen)
+	for ii := uint(0); ii < inputLen; ii++ {
+		ch := input[ii]
+		if ch >= 'A' && ch <= 'Z' {
+			ch += 'a' - 'A'
+		}
+		dupe[ii] = ch
+	}
+	return dupe
 }
 
 func mkargs(args ...interface{}) []interface{} {
 	return args
 }
 
+func (uuid UUID) uint128() (uint64, uint64) {
+	hi := binary.BigEndian.Uint64(uuid[0:8])
+	lo := binary.BigEndian.Uint64(uuid[8:16])
+	return hi, lo
+}
+
+func (uuid *UUID) putUint128(hi uint64, lo uint64) {
+	binary.BigEndian.PutUint64(uuid[0:8], hi)
+	binary.BigEndian.PutUint64(uuid[8:16], lo)
+}
+
 func getUint48(in []byte) uint64 {
 	var tmp [8]byte
 	copy(tmp[2:8], in[0:6])
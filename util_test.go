@@ -0,0 +1,19 @@
+package youyouayedee
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestIsErrClockNotFound(t *testing.T) {
+	compare[bool](t, "ErrClockNotFound", true, isErrClockNotFound(ErrClockNotFound{}))
+	compare[bool](t, "wrapped", true, isErrClockNotFound(fmt.Errorf("load: %w", ErrClockNotFound{})))
+	compare[bool](t, "other", false, isErrClockNotFound(io.EOF))
+	compare[bool](t, "nil", false, isErrClockNotFound(nil))
+
+	// ClockStorageUnavailable always reports ErrClockNotFound, which must
+	// not prevent a generator from starting.
+	_, err := NewTimeGenerator(7, Options{ForceRandomNode: true})
+	compareError(t, "NewTimeGenerator", nil, err)
+}
@@ -8,7 +8,12 @@ import (
 // Size is the size of a UUID in bytes.
 const Size = 16
 
-// UUID represents a UUID.  Only the RFC 4122 variant is supported.
+// UUID represents a UUID.
+//
+// This library is primarily concerned with the RFC 4122 variant, but UUIDs of
+// other variants can be stored, parsed with ParseOptions.AcceptAnyVariant, and
+// identified with the Variant method.
+//
 type UUID [Size]byte
 
 // Nil is the nil UUID, "00000000-0000-0000-0000-000000000000".
@@ -36,6 +41,25 @@ func (uuid UUID) IsValid() bool {
 	return (uuid[8] & 0xc0) == 0x80
 }
 
+// Variant returns the UUID's variant field.
+//
+// Note that the nil UUID is technically an NCS variant UUID, and the max UUID
+// is technically a reserved variant UUID.
+//
+func (uuid UUID) Variant() Variant {
+	vb := uuid[8]
+	switch {
+	case (vb & 0x80) == 0x00:
+		return VariantNCS
+	case (vb & 0xc0) == 0x80:
+		return VariantRFC4122
+	case (vb & 0xe0) == 0xc0:
+		return VariantMicrosoft
+	default:
+		return VariantReserved
+	}
+}
+
 // Version returns the UUID's version field.
 //
 // This value may not be meaningful if IsValid would return false.
@@ -116,6 +140,46 @@ func (uuid UUID) AppendTo(out []byte) []byte {
 	return out
 }
 
+// AppendGUIDBytes appends the UUID's 16 bytes to the given []byte, using the
+// mixed-endian layout of a Microsoft GUID structure.  The first three fields
+// (4, 2, and 2 bytes) are stored little-endian, and the remaining 8 bytes are
+// stored as-is.
+//
+// This is the byte order used by Windows APIs, by .NET's Guid.ToByteArray,
+// and by SQL Server's binary representation of uniqueidentifier.
+//
+func (uuid UUID) AppendGUIDBytes(out []byte) []byte {
+	out = append(out, uuid[3], uuid[2], uuid[1], uuid[0])
+	out = append(out, uuid[5], uuid[4])
+	out = append(out, uuid[7], uuid[6])
+	out = append(out, uuid[8:16]...)
+	return out
+}
+
+// FromGUIDBytes converts 16 bytes in the mixed-endian layout of a Microsoft
+// GUID structure into a UUID.  It is the inverse of AppendGUIDBytes.
+//
+// The variant is not checked, as Microsoft GUIDs are frequently not RFC 4122
+// variant UUIDs.
+//
+func FromGUIDBytes(buf []byte) (UUID, error) {
+	bufLen := uint(len(buf))
+	if bufLen != Size {
+		return Nil, ErrParseFailed{
+			Input:   buf,
+			Problem: WrongInputLength,
+			Args:    mkargs(bufLen, "GUID bytes", uint(Size)),
+		}
+	}
+
+	var uuid UUID
+	uuid[0], uuid[1], uuid[2], uuid[3] = buf[3], buf[2], buf[1], buf[0]
+	uuid[4], uuid[5] = buf[5], buf[4]
+	uuid[6], uuid[7] = buf[7], buf[6]
+	copy(uuid[8:16], buf[8:16])
+	return uuid, nil
+}
+
 // Parse parses a UUID from a string.
 func Parse(str string) (UUID, error) {
 	var tmp [64]byte
@@ -1,8 +1,12 @@
 package youyouayedee
 
 import (
+	"encoding/hex"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParse(t *testing.T) {
@@ -187,6 +191,27 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestUnmarshalBinary(t *testing.T) {
+	// Binary UUIDs must never be case-folded, even when some of their
+	// bytes happen to be ASCII capital letters or invalid UTF-8.
+	inputs := [...][]byte{
+		{0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48, 0x89, 0x5a, 0x4b, 0x4c, 0x4d, 0x4e, 0x4f, 0x50},
+		{0xc3, 0x41, 0xff, 0x5a, 0x00, 0x01, 0x42, 0x4f, 0x80, 0x59, 0xe2, 0x82, 0x41, 0x4d, 0x4e, 0x58},
+	}
+
+	for _, input := range inputs {
+		t.Run(hex.EncodeToString(input), func(t *testing.T) {
+			var uuid UUID
+			compareError(t, "UnmarshalBinary", nil, uuid.UnmarshalBinary(input))
+			compare[string](t, "UnmarshalBinary", hex.EncodeToString(input), hex.EncodeToString(uuid[:]))
+
+			var scanned UUID
+			compareError(t, "Scan", nil, scanned.Scan(input))
+			compare[UUID](t, "Scan", uuid, scanned)
+		})
+	}
+}
+
 func TestUUID(t *testing.T) {
 	type testRow struct {
 		Name    string
@@ -334,3 +359,364 @@ func TestUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestVariant(t *testing.T) {
+	type testRow struct {
+		Name    string
+		Input   string
+		Variant Variant
+		Str     string
+	}
+
+	testData := [...]testRow{
+		{Name: "nil", Input: "00000000-0000-0000-0000-000000000000", Variant: VariantNCS, Str: "NCS backward compatibility"},
+		{Name: "NCS", Input: "333a2276-0000-0000-0d00-00809c000000", Variant: VariantNCS, Str: "NCS backward compatibility"},
+		{Name: "RFC 4122", Input: "d3ef7600-6a95-11ec-9234-2358840c40e6", Variant: VariantRFC4122, Str: "RFC 4122"},
+		{Name: "Microsoft", Input: "00000000-0000-0000-c000-000000000046", Variant: VariantMicrosoft, Str: "Microsoft backward compatibility"},
+		{Name: "reserved", Input: "00000000-0000-0000-e000-000000000000", Variant: VariantReserved, Str: "reserved for future definition"},
+		{Name: "max", Input: "ffffffff-ffff-ffff-ffff-ffffffffffff", Variant: VariantReserved, Str: "reserved for future definition"},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Name)
+		t.Run(testName, func(t *testing.T) {
+			uuid, err := ParseOptions{AcceptAnyVariant: true}.Parse(row.Input)
+			compareError(t, "Parse", nil, err)
+			compare[Variant](t, "Variant", row.Variant, uuid.Variant())
+			compare[string](t, "String", row.Str, uuid.Variant().String())
+			compare[string](t, "UUID", row.Input, uuid.String())
+
+			_, err = Parse(row.Input)
+			if row.Variant == VariantRFC4122 || uuid.IsZero() || uuid.IsMax() {
+				compareError(t, "Parse", nil, err)
+			} else if xerr, ok := err.(ErrParseFailed); !ok || xerr.Problem != WrongVariant {
+				t.Errorf("Parse: expected WrongVariant, got %#v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeNCS(t *testing.T) {
+	uuid := Must(ParseOptions{AcceptAnyVariant: true}.Parse("333a2276-0000-0000-0d00-00809c000000"))
+	decoded := uuid.Decode(nil)
+	compare[bool](t, "Valid", false, decoded.Valid)
+	compare[Variant](t, "Variant", VariantNCS, decoded.Variant)
+	compare[bool](t, "HasTicks", true, decoded.HasTicks)
+	compare[int64](t, "Ticks", 0x333a22760000, decoded.Ticks)
+	compare[time.Time](t, "Time", time.Date(1987, time.February, 20, 15, 5, 17, 113344000, time.UTC), decoded.Time.UTC())
+	compare[string](t, "Data", "00000d0000809c000000", hex.EncodeToString(decoded.Data))
+
+	decoded = Nil.Decode(nil)
+	compare[bool](t, "Valid", false, decoded.Valid)
+}
+
+func TestDecodeOtherVariants(t *testing.T) {
+	type testRow struct {
+		Input   string
+		Variant Variant
+	}
+
+	testData := [...]testRow{
+		{"00112233-4455-6677-c899-aabbccddeeff", VariantMicrosoft},
+		{"00112233-4455-6677-e899-aabbccddeeff", VariantReserved},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Input, func(t *testing.T) {
+			uuid := Must(ParseOptions{AcceptAnyVariant: true}.Parse(row.Input))
+			decoded := uuid.Decode(nil)
+			compare[bool](t, "Valid", false, decoded.Valid)
+			compare[Variant](t, "Variant", row.Variant, decoded.Variant)
+			compare[Version](t, "Version", 0, decoded.Version)
+			compare[bool](t, "HasTicks", false, decoded.HasTicks)
+			compare[bool](t, "HasData", true, decoded.HasData)
+			compare[string](t, "Data", strings.ReplaceAll(row.Input, "-", ""), hex.EncodeToString(decoded.Data))
+
+			encoded, err := decoded.Encode(nil)
+			compareError(t, "Encode", nil, err)
+			compare[UUID](t, "Encode", uuid, encoded)
+		})
+	}
+}
+
+func TestGUID(t *testing.T) {
+	uuid := Must(ParseOptions{AcceptAnyVariant: true}.Parse("00112233-4455-6677-8899-aabbccddeeff"))
+	guidBytes := []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+
+	compare[string](t, "AppendGUIDBytes", hex.EncodeToString(guidBytes), hex.EncodeToString(uuid.AppendGUIDBytes(nil)))
+
+	actual, err := FromGUIDBytes(guidBytes)
+	compareError(t, "FromGUIDBytes", nil, err)
+	compare[UUID](t, "FromGUIDBytes", uuid, actual)
+
+	_, err = FromGUIDBytes(guidBytes[:15])
+	compareError(t, "FromGUIDBytes", ErrParseFailed{Input: guidBytes[:15], Problem: WrongInputLength, Args: mkargs(uint(15), "GUID bytes", uint(Size))}, err)
+	compare[string](t, "FromGUIDBytes/Error", "failed to parse 33:22:11:00:55:44:77:66:88:99:aa:bb:cc:dd:ee as UUID: unexpected input length 15 for GUID bytes; should be 16", err.Error())
+
+	guid := GUID{UUID: uuid}
+	value, err := guid.Value()
+	compareError(t, "Value", nil, err)
+	compare[string](t, "Value", hex.EncodeToString(guidBytes), hex.EncodeToString(value.([]byte)))
+
+	binary, err := guid.MarshalBinary()
+	compareError(t, "MarshalBinary", nil, err)
+	compare[string](t, "MarshalBinary", hex.EncodeToString(guidBytes), hex.EncodeToString(binary))
+
+	text, err := guid.MarshalText()
+	compareError(t, "MarshalText", nil, err)
+	compare[string](t, "MarshalText", "00112233-4455-6677-8899-aabbccddeeff", string(text))
+
+	var scanned GUID
+	compareError(t, "Scan", nil, scanned.Scan(guidBytes))
+	compare[UUID](t, "Scan", uuid, scanned.UUID)
+	compareError(t, "Scan", nil, scanned.Scan("{00112233-4455-6677-8899-AABBCCDDEEFF}"))
+	compare[UUID](t, "Scan", uuid, scanned.UUID)
+
+	var unmarshaled GUID
+	compareError(t, "UnmarshalBinary", nil, unmarshaled.UnmarshalBinary(guidBytes))
+	compare[UUID](t, "UnmarshalBinary", uuid, unmarshaled.UUID)
+	compareError(t, "UnmarshalJSON", nil, unmarshaled.UnmarshalJSON([]byte(`"00112233-4455-6677-8899-aabbccddeeff"`)))
+	compare[UUID](t, "UnmarshalJSON", uuid, unmarshaled.UUID)
+}
+
+func TestParseOptions(t *testing.T) {
+	type testRow struct {
+		Name    string
+		Options ParseOptions
+		Binary  bool
+		Input   []byte
+		Output  UUID
+		Err     error
+	}
+
+	strV1Upper := []byte("D3EF7600-6A95-11EC-9234-2358840C40E6")
+	strV1 := []byte("d3ef7600-6a95-11ec-9234-2358840c40e6")
+	strV1Brace := []byte("{d3ef7600-6a95-11ec-9234-2358840c40e6}")
+	strNilDash := []byte("00000000-0000-0000-0000-000000000000")
+	binV1 := uuidV1[:]
+	binUpper := []byte("ABCDEFGHIJKLMNOP")
+	strict := StrictParseOptions()
+
+	testData := [...]testRow{
+		{Name: "strict/canonical", Options: strict, Input: strV1, Output: uuidV1},
+		{
+			Name:    "strict/upper",
+			Options: strict,
+			Input:   strV1Upper,
+			Err: ErrParseFailed{
+				Input:      strV1Upper,
+				Problem:    UnexpectedCharacter,
+				Args:       mkargs(byte('D'), uint(0), "lower case character"),
+				Index:      0,
+				ActualByte: 'D',
+			},
+		},
+		{
+			Name:    "strict/braced",
+			Options: strict,
+			Input:   strV1Brace,
+			Err:     ErrParseFailed{Input: strV1Brace, Problem: DisallowedFormat, Args: mkargs(FormatBraced)},
+		},
+		{
+			Name:    "strict/binary",
+			Options: strict,
+			Binary:  true,
+			Input:   binV1,
+			Err:     ErrParseFailed{Input: binV1, Problem: DisallowedFormat, Args: mkargs(FormatBinary)},
+		},
+		{
+			Name:    "strict/empty",
+			Options: strict,
+			Input:   strEmpty,
+			Err:     ErrParseFailed{Input: strEmpty, Problem: DisallowedKeyword, Args: mkargs("")},
+		},
+		{
+			Name:    "strict/null",
+			Options: strict,
+			Input:   strNull,
+			Err:     ErrParseFailed{Input: strNull, Problem: DisallowedKeyword, Args: mkargs("null")},
+		},
+		{
+			Name:    "strict/nil-dash",
+			Options: strict,
+			Input:   strNilDash,
+			Err:     ErrParseFailed{Input: strNilDash, Problem: DisallowedValue, Args: mkargs("nil")},
+		},
+		{
+			Name:    "reject-nil/max",
+			Options: ParseOptions{RejectNilAndMax: true},
+			Input:   strMax,
+			Err:     ErrParseFailed{Input: strMax, Problem: DisallowedValue, Args: mkargs("max")},
+		},
+		{Name: "keywords-only/nil-dash", Options: ParseOptions{RejectKeywords: true}, Input: strNilDash, Output: Nil},
+		{Name: "versions/ok", Options: ParseOptions{Versions: []Version{1, 6}}, Input: strV1Upper, Output: uuidV1},
+		{
+			Name:    "versions/wrong",
+			Options: ParseOptions{Versions: []Version{4, 7}},
+			Input:   strV1,
+			Err:     ErrParseFailed{Input: strV1, Problem: WrongVersion, Args: mkargs(byte(1), []Version{4, 7})},
+		},
+		{
+			Name:    "binary/upper-case-bytes",
+			Options: ParseOptions{AcceptAnyVariant: true},
+			Binary:  true,
+			Input:   binUpper,
+			Output:  UUID{'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P'},
+		},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Name)
+		t.Run(testName, func(t *testing.T) {
+			var uuid UUID
+			var err error
+			if row.Binary {
+				uuid, err = row.Options.ParseBytes(row.Input)
+			} else {
+				uuid, err = row.Options.Parse(string(row.Input))
+			}
+			compare[UUID](t, "Parse", row.Output, uuid)
+			compareError(t, "Parse", row.Err, err)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	type testRow struct {
+		Format string
+		Expect string
+	}
+
+	testData := [...]testRow{
+		{Format: "%s", Expect: "d3ef7600-6a95-11ec-9234-2358840c40e6"},
+		{Format: "%v", Expect: "d3ef7600-6a95-11ec-9234-2358840c40e6"},
+		{Format: "%+v", Expect: "d3ef7600-6a95-11ec-9234-2358840c40e6"},
+		{Format: "%#v", Expect: "youyouayedee.UUID{0xd3, 0xef, 0x76, 0x00, 0x6a, 0x95, 0x11, 0xec, 0x92, 0x34, 0x23, 0x58, 0x84, 0x0c, 0x40, 0xe6}"},
+		{Format: "%x", Expect: "d3ef76006a9511ec92342358840c40e6"},
+		{Format: "%X", Expect: "D3EF76006A9511EC92342358840C40E6"},
+		{Format: "%q", Expect: `"d3ef7600-6a95-11ec-9234-2358840c40e6"`},
+		{Format: "%#s", Expect: "{d3ef7600-6a95-11ec-9234-2358840c40e6}"},
+		{Format: "%+s", Expect: "urn:uuid:d3ef7600-6a95-11ec-9234-2358840c40e6"},
+		{Format: "%#X", Expect: "{D3EF7600-6A95-11EC-9234-2358840C40E6}"},
+		{Format: "%+q", Expect: `"urn:uuid:d3ef7600-6a95-11ec-9234-2358840c40e6"`},
+		{Format: "%40s|", Expect: "    d3ef7600-6a95-11ec-9234-2358840c40e6|"},
+		{Format: "%-40s|", Expect: "d3ef7600-6a95-11ec-9234-2358840c40e6    |"},
+		{Format: "%d", Expect: "%!d(youyouayedee.UUID=d3ef7600-6a95-11ec-9234-2358840c40e6)"},
+	}
+
+	for index, row := range testData {
+		testName := fmt.Sprintf("%05d/%s", index, row.Format)
+		t.Run(testName, func(t *testing.T) {
+			compare[string](t, "Sprintf", row.Expect, fmt.Sprintf(row.Format, uuidV1))
+		})
+	}
+
+	t.Run("Struct", func(t *testing.T) {
+		type wrapper struct {
+			ID UUID
+		}
+		w := wrapper{ID: uuidV1}
+		compare[string](t, "Sprintf %v", "{d3ef7600-6a95-11ec-9234-2358840c40e6}", fmt.Sprintf("%v", w))
+		compare[string](t, "Sprintf %+v", "{ID:d3ef7600-6a95-11ec-9234-2358840c40e6}", fmt.Sprintf("%+v", w))
+	})
+
+	// Box the UUID once, so that only Format itself is measured.
+	var boxed interface{} = uuidV1
+	for _, verb := range [...]string{"%s", "%v", "%#v", "%x", "%X", "%q", "%#s", "%+s", "%-40s"} {
+		t.Run("AllocsPerRun/"+verb, func(t *testing.T) {
+			if raceEnabled {
+				t.Skip("fmt allocates at random under the race detector")
+			}
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _ = fmt.Fprintf(io.Discard, verb, boxed)
+			})
+			compare[float64](t, "AllocsPerRun", 0, allocs)
+		})
+	}
+
+	formats := [...]Format{FormatCanonical, FormatHex, FormatBraced, FormatURN, FormatBinary}
+	for _, format := range formats {
+		t.Run(format.String(), func(t *testing.T) {
+			var tmp [64]byte
+			allocs := testing.AllocsPerRun(100, func() {
+				_ = format.Append(tmp[:0], uuidV1)
+				_ = format.AppendUpper(tmp[:0], uuidV1)
+			})
+			compare[float64](t, "AllocsPerRun", 0, allocs)
+
+			lower := format.Append(nil, uuidV1)
+			compare[int](t, "Len", format.Len(), len(lower))
+
+			var parsed UUID
+			var err error
+			if format == FormatBinary {
+				parsed, err = ParseBytes(lower)
+			} else {
+				parsed, err = Parse(string(format.AppendUpper(nil, uuidV1)))
+			}
+			compareError(t, "Parse", nil, err)
+			compare[UUID](t, "Parse", uuidV1, parsed)
+		})
+	}
+}
+
+func TestULID(t *testing.T) {
+	const ulidText = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	expect := Must(ParseOptions{AcceptAnyVariant: true}.Parse("01563e3ab5d3d6764c61efb99302bd5b"))
+
+	parsed, err := ParseULID(ulidText)
+	compareError(t, "ParseULID", nil, err)
+	compare[UUID](t, "ParseULID", expect, parsed)
+	compare[string](t, "ULID", ulidText, parsed.ULID())
+
+	parsed, err = ParseULID("01arz3ndektsv4rrffq69g5fav")
+	compareError(t, "ParseULID/lower", nil, err)
+	compare[UUID](t, "ParseULID/lower", expect, parsed)
+
+	parsed, err = ParseULID("OLARZ3NDEKTSV4RRFFQ69G5FAV")
+	compareError(t, "ParseULID/alias", nil, err)
+	compare[UUID](t, "ParseULID/alias", expect, parsed)
+
+	compare[string](t, "ULID/Max", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", Max.ULID())
+	compare[string](t, "ULID/Nil", "00000000000000000000000000", Nil.ULID())
+
+	_, err = ParseULID("8ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	compareError(t, "ParseULID/overflow", ErrParseFailed{
+		Input:   []byte("8ZZZZZZZZZZZZZZZZZZZZZZZZZ"),
+		Problem: ValueOverflow,
+		Args:    mkargs("base32"),
+	}, err)
+
+	_, err = ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAU")
+	compareError(t, "ParseULID/badchar", ErrParseFailed{
+		Input:      []byte("01ARZ3NDEKTSV4RRFFQ69G5FAU"),
+		Problem:    UnexpectedCharacter,
+		Args:       mkargs(byte('U'), uint(25), "Crockford base32 digit"),
+		Index:      25,
+		ActualByte: 'U',
+	}, err)
+
+	_, err = ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FA")
+	compareError(t, "ParseULID/short", ErrParseFailed{
+		Input:   []byte("01ARZ3NDEKTSV4RRFFQ69G5FA"),
+		Problem: WrongInputLength,
+		Args:    mkargs(uint(25), "ULID", uint(26)),
+	}, err)
+	compare[string](t, "ParseULID/short/Error", `failed to parse "01ARZ3NDEKTSV4RRFFQ69G5FA" as UUID: unexpected input length 25 for ULID; should be 26`, err.Error())
+
+	v7 := ULIDToV7(expect)
+	compare[bool](t, "IsValid", true, v7.IsValid())
+	compare[Version](t, "Version", 7, v7.Version())
+	decoded := v7.Decode(nil)
+	compare[bool](t, "Valid", true, decoded.Valid)
+	compare[int64](t, "Ticks", 1469922850259, decoded.Ticks)
+
+	_, err = Parse(ulidText)
+	compare[bool](t, "Parse/default", true, err != nil)
+
+	opts := ParseOptions{Formats: []Format{FormatCanonical, FormatULID}, AcceptAnyVariant: true}
+	parsed, err = opts.Parse(ulidText)
+	compareError(t, "ParseOptions", nil, err)
+	compare[UUID](t, "ParseOptions", expect, parsed)
+	compare[string](t, "Append", ulidText, string(FormatULID.Append(nil, expect)))
+}
@@ -0,0 +1,674 @@
+package youyouayedee
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// V8Field describes one field of a V8Layout.
+//
+// Bits are numbered from 0 for the most significant bit of the first byte of
+// the UUID, through 127 for the least significant bit of the last byte, so
+// that a field's bits read in the same order as the UUID's hex digits.
+//
+type V8Field struct {
+	// Name identifies the field within its layout.
+	Name string
+
+	// Kind indicates the meaning of the field.
+	Kind V8FieldKind
+
+	// Offset is the bit index of the field's most significant bit.
+	Offset uint
+
+	// Width is the number of bits in the field, from 1 to 64.
+	Width uint
+
+	// Value is the fixed value of a V8FieldConstant field.  Constant
+	// fields are what distinguish one registered layout from another.
+	Value uint64
+
+	// Unit is the duration of one tick of a V8FieldTimestamp field.  It
+	// must either evenly divide one second or be a whole number of
+	// seconds.  If zero, then time.Millisecond is used.
+	Unit time.Duration
+
+	// Epoch is the time at which a V8FieldTimestamp field is zero.  If
+	// zero, then the Unix epoch is used.
+	Epoch time.Time
+}
+
+// V8Layout describes a user-defined bit layout for V8 UUIDs.
+//
+// Fields may not overlap each other, and may not overlap the 4 version bits
+// (offsets 48 to 51) or the 2 variant bits (offsets 64 and 65).  Bits which
+// are not covered by any field are zero in UUIDs built with Encode, and are
+// random in UUIDs produced by NewV8Generator.
+//
+// A layout may contain at most one V8FieldTimestamp field and at most one
+// V8FieldCounter field, which may be at most 32 bits wide.  Decode reports
+// these through the Time, Ticks, and Counter fields of Decoded.
+//
+// Layouts built with NewV8Layout, or returned by a V8Registry, are checked
+// once and remember the result, and must not be modified afterward.  Other
+// layouts are checked again on every method call.
+//
+type V8Layout struct {
+	Name   string
+	Fields []V8Field
+
+	compiled *v8Compiled
+}
+
+// NewV8Layout constructs a V8Layout with the given name and fields, failing
+// with ErrV8LayoutNotValid if it is not well-formed.
+//
+func NewV8Layout(name string, fields ...V8Field) (V8Layout, error) {
+	c, err := compileV8Layout(V8Layout{Name: name, Fields: fields})
+	if err != nil {
+		return V8Layout{}, err
+	}
+	return c.layout, nil
+}
+
+// Validate checks that the layout is well-formed.
+func (layout V8Layout) Validate() error {
+	_, err := layout.compile()
+	return err
+}
+
+// Matches returns true iff the given UUID is a V8 UUID whose constant fields
+// match this layout.  It returns false if the layout is not well-formed.
+//
+func (layout V8Layout) Matches(uuid UUID) bool {
+	c, err := layout.compile()
+	return err == nil && c.matches(uuid)
+}
+
+// Decode breaks down the given UUID like UUID.Decode, and then, if the UUID
+// matches this layout, also fills in the Layout and Fields fields of the
+// result, plus the Time, Ticks, and Counter fields if the layout has
+// timestamp or counter fields.  It returns true iff the UUID matched.
+//
+func (layout V8Layout) Decode(uuid UUID) (Decoded, bool) {
+	result := uuid.Decode(nil)
+	c, err := layout.compile()
+	if err != nil || !c.matches(uuid) {
+		return result, false
+	}
+	c.decode(&result, uuid)
+	return result, true
+}
+
+// Encode builds a V8 UUID using this layout.
+//
+// The values map supplies the raw values of the non-constant fields, keyed by
+// field name.  Missing fields are zero.  Values which do not fit within their
+// field's width, and names which do not match any non-constant field, are
+// rejected with ErrV8LayoutNotValid.
+//
+func (layout V8Layout) Encode(values map[string]uint64) (UUID, error) {
+	c, err := layout.compile()
+	if err != nil {
+		return Nil, err
+	}
+	return c.encode(values)
+}
+
+// Extract returns the raw values of all fields of the given UUID, keyed by
+// field name.  The UUID is not checked against the layout.
+//
+func (layout V8Layout) Extract(uuid UUID) map[string]uint64 {
+	hi, lo := uuid.uint128()
+	out := make(map[string]uint64, len(layout.Fields))
+	for _, field := range layout.Fields {
+		out[field.Name] = getBits128(hi, lo, field.Offset, field.Width)
+	}
+	return out
+}
+
+// TimestampValue converts a time to the raw value of the layout's
+// V8FieldTimestamp field.  It fails if the layout has no such field, or if the
+// time cannot be represented.
+//
+func (layout V8Layout) TimestampValue(t time.Time) (uint64, error) {
+	c, err := layout.compile()
+	if err != nil {
+		return 0, err
+	}
+	if c.timestamp < 0 {
+		return 0, ErrV8LayoutNotValid{Layout: layout.Name, Message: "layout has no timestamp field"}
+	}
+	return c.timestampValue(t)
+}
+
+func (layout V8Layout) compile() (*v8Compiled, error) {
+	if layout.compiled != nil {
+		return layout.compiled, nil
+	}
+	return compileV8Layout(layout)
+}
+
+// V8Registry holds a set of named V8Layouts with mutually exclusive constant
+// fields, so that each V8 UUID matches at most one of them.  It is safe for
+// concurrent use.
+//
+type V8Registry struct {
+	mu      sync.RWMutex
+	layouts []*v8Compiled
+}
+
+// Register adds a layout to the registry.
+//
+// It fails with ErrV8LayoutNotValid if the layout is not well-formed or if its
+// name is already registered, and with ErrV8LayoutAmbiguous if some V8 UUID
+// could match both it and an already registered layout, i.e. if there is no
+// bit at which both layouts have constant fields with differing values.
+//
+func (r *V8Registry) Register(layout V8Layout) error {
+	c, err := layout.compile()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.layouts {
+		if existing.layout.Name == c.layout.Name {
+			return ErrV8LayoutNotValid{Layout: c.layout.Name, Message: "a layout with this name is already registered"}
+		}
+		if existing.overlaps(c) {
+			return ErrV8LayoutAmbiguous{Layout: c.layout.Name, Existing: existing.layout.Name}
+		}
+	}
+
+	r.layouts = append(r.layouts, c)
+	return nil
+}
+
+// Unregister removes the named layout from the registry.  It returns true iff
+// the layout was found.
+//
+func (r *V8Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index, existing := range r.layouts {
+		if existing.layout.Name == name {
+			r.layouts = append(r.layouts[:index:index], r.layouts[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Layout returns the registered layout with the given name.
+func (r *V8Registry) Layout(name string) (V8Layout, bool) {
+	if c := r.byName(name); c != nil {
+		return c.layout, true
+	}
+	return V8Layout{}, false
+}
+
+// Lookup returns the registered layout which matches the given UUID.
+func (r *V8Registry) Lookup(uuid UUID) (V8Layout, bool) {
+	if c := r.lookup(uuid); c != nil {
+		return c.layout, true
+	}
+	return V8Layout{}, false
+}
+
+// Decode breaks down the given UUID like UUID.Decode, and then, if the UUID
+// matches a registered layout, also decodes it according to that layout, as
+// V8Layout.Decode does.
+//
+func (r *V8Registry) Decode(uuid UUID) Decoded {
+	return uuid.Decode(nil, r)
+}
+
+func (r *V8Registry) byName(name string) *v8Compiled {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.layouts {
+		if c.layout.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (r *V8Registry) lookup(uuid UUID) *v8Compiled {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.layouts {
+		if c.matches(uuid) {
+			return c
+		}
+	}
+	return nil
+}
+
+// NewV8Generator constructs a new Generator that produces V8 UUIDs with the
+// given layout.
+//
+// Constant fields take their values from the layout, and V8FieldValue fields
+// take theirs from the values map.  The timestamp field, if any, is filled
+// from o.TimeSource.  The counter field, if any, starts at zero for each new
+// timestamp value and is incremented for each UUID generated within the same
+// timestamp value, or while the clock is behind the last timestamp value
+// used.  Random fields and any bits not covered by a field are filled from
+// o.RandomSource.
+//
+// If the counter is exhausted, then NewUUID fails with ErrSequenceOverflow
+// until the clock reaches the next timestamp value, so that the (timestamp,
+// counter) pair never repeats or decreases.
+//
+// The last timestamp and counter values are saved to o.ClockStorage after
+// every UUID, keyed by o.Node, so that a restarted generator does not reuse
+// them.  As with NewTimeGenerator, a Node is generated if o.Node is zero.
+//
+func NewV8Generator(layout V8Layout, values map[string]uint64, o Options) (Generator, error) {
+	c, err := layout.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range c.layout.Fields {
+		if field.Kind != V8FieldValue {
+			continue
+		}
+		if err := c.checkValue(field, values[field.Name]); err != nil {
+			return nil, err
+		}
+	}
+	for name := range values {
+		if index := c.fieldIndex(name); index < 0 || c.layout.Fields[index].Kind != V8FieldValue {
+			return nil, ErrV8LayoutNotValid{Layout: c.layout.Name, Field: name, Message: "no such value field"}
+		}
+	}
+
+	fixed := make(map[string]uint64, len(values))
+	for name, value := range values {
+		fixed[name] = value
+	}
+
+	g := &genV8{
+		c:      c,
+		values: fixed,
+		rng:    o.RandomSource,
+	}
+	if err := g.state.init(o); err != nil {
+		return nil, err
+	}
+
+	// Saved state which this layout cannot represent, e.g. from a
+	// different layout, is ignored.
+	if g.state.started && c.timestamp >= 0 {
+		if _, err := c.timestampValue(g.state.last); err != nil {
+			g.state.started = false
+		}
+	}
+	if c.counter >= 0 && uint64(g.state.clock) > c.counterMax() {
+		g.state.clock = uint32(c.counterMax())
+	}
+	return g, nil
+}
+
+type genV8 struct {
+	GeneratorBase
+
+	c      *v8Compiled
+	values map[string]uint64
+	rng    io.Reader
+	state  clockState
+}
+
+func (g *genV8) NewUUID() (UUID, error) {
+	var uuid UUID
+	if err := readRandom(g.rng, uuid[:]); err != nil {
+		return Nil, err
+	}
+	hi, lo := uuid.uint128()
+
+	c := g.c
+	var ts, counter uint64
+	err := g.state.advance(func(now time.Time) (time.Time, uint32, error) {
+		var lastTS uint64
+		if c.timestamp >= 0 {
+			var err error
+			ts, err = c.timestampValue(now)
+			if err != nil {
+				return time.Time{}, 0, err
+			}
+			if g.state.started {
+				lastTS, _ = c.timestampValue(g.state.last)
+			}
+		}
+
+		counter = uint64(g.state.clock)
+		if c.counter >= 0 {
+			switch {
+			case !g.state.started || ts > lastTS:
+				counter = 0
+			case counter < c.counterMax():
+				ts = lastTS
+				counter++
+			default:
+				return time.Time{}, 0, ErrSequenceOverflow{Layout: c.layout.Name, Time: c.timeFromValue(lastTS)}
+			}
+		} else if g.state.started && ts < lastTS {
+			ts = lastTS
+		}
+		return c.timeFromValue(ts), uint32(counter), nil
+	})
+	if err != nil {
+		return Nil, err
+	}
+
+	for _, field := range c.layout.Fields {
+		var value uint64
+		switch field.Kind {
+		case V8FieldConstant:
+			value = field.Value
+		case V8FieldTimestamp:
+			value = ts
+		case V8FieldCounter:
+			value = counter
+		case V8FieldRandom:
+			continue
+		case V8FieldValue:
+			value = g.values[field.Name]
+		}
+		hi, lo = putBits128(hi, lo, field.Offset, field.Width, value)
+	}
+
+	uuid.putUint128(hi, lo)
+	uuid[6] = (uuid[6] & 0x0f) | 0x80
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return uuid, nil
+}
+
+var _ Generator = (*genV8)(nil)
+
+type v8Compiled struct {
+	layout    V8Layout
+	constHi   uint64
+	constLo   uint64
+	valueHi   uint64
+	valueLo   uint64
+	timestamp int
+	counter   int
+}
+
+func compileV8Layout(layout V8Layout) (*v8Compiled, error) {
+	fail := func(field string, format string, args ...interface{}) (*v8Compiled, error) {
+		return nil, ErrV8LayoutNotValid{Layout: layout.Name, Field: field, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if layout.Name == "" {
+		return fail("", "layout name must not be empty")
+	}
+
+	c := &v8Compiled{
+		layout:    V8Layout{Name: layout.Name, Fields: make([]V8Field, len(layout.Fields))},
+		timestamp: -1,
+		counter:   -1,
+	}
+	copy(c.layout.Fields, layout.Fields)
+
+	// The version and variant bits are always in use.
+	usedHi, usedLo := bitMask128(48, 4)
+	vh, vl := bitMask128(64, 2)
+	usedHi, usedLo = usedHi|vh, usedLo|vl
+
+	names := make(map[string]struct{}, len(layout.Fields))
+	for index := range c.layout.Fields {
+		field := &c.layout.Fields[index]
+
+		if field.Name == "" {
+			return fail("", "field %d has an empty name", index)
+		}
+		if _, found := names[field.Name]; found {
+			return fail(field.Name, "duplicate field name")
+		}
+		names[field.Name] = struct{}{}
+
+		if !field.Kind.IsValid() {
+			return fail(field.Name, "unknown field kind %d", uint(field.Kind))
+		}
+		if field.Width < 1 || field.Width > 64 {
+			return fail(field.Name, "width %d is out of range [1, 64]", field.Width)
+		}
+		if field.Offset >= 128 || field.Offset+field.Width > 128 {
+			return fail(field.Name, "bits [%d, %d) extend past the end of the UUID", field.Offset, field.Offset+field.Width)
+		}
+
+		mh, ml := bitMask128(field.Offset, field.Width)
+		if (usedHi&mh) != 0 || (usedLo&ml) != 0 {
+			return fail(field.Name, "bits [%d, %d) overlap another field or the version or variant bits", field.Offset, field.Offset+field.Width)
+		}
+		usedHi, usedLo = usedHi|mh, usedLo|ml
+
+		switch field.Kind {
+		case V8FieldConstant:
+			if field.Value > widthMask(field.Width) {
+				return fail(field.Name, "constant value %d does not fit in %d bits", field.Value, field.Width)
+			}
+			c.constHi, c.constLo = c.constHi|mh, c.constLo|ml
+			c.valueHi, c.valueLo = putBits128(c.valueHi, c.valueLo, field.Offset, field.Width, field.Value)
+
+		case V8FieldTimestamp:
+			if c.timestamp >= 0 {
+				return fail(field.Name, "layout has more than one timestamp field")
+			}
+			if field.Unit == 0 {
+				field.Unit = time.Millisecond
+			}
+			if field.Unit < 0 || (field.Unit < time.Second && time.Second%field.Unit != 0) || (field.Unit > time.Second && field.Unit%time.Second != 0) {
+				return fail(field.Name, "unit %v must evenly divide one second or be a whole number of seconds", field.Unit)
+			}
+			if field.Epoch.IsZero() {
+				field.Epoch = time.Unix(0, 0)
+			}
+			c.timestamp = index
+
+		case V8FieldCounter:
+			if c.counter >= 0 {
+				return fail(field.Name, "layout has more than one counter field")
+			}
+			if field.Width > 32 {
+				return fail(field.Name, "counter width %d is out of range [1, 32]", field.Width)
+			}
+			c.counter = index
+		}
+	}
+
+	c.layout.compiled = c
+	return c, nil
+}
+
+func (c *v8Compiled) matches(uuid UUID) bool {
+	if uuid.Variant() != VariantRFC4122 || uuid.Version() != 8 {
+		return false
+	}
+	hi, lo := uuid.uint128()
+	return ((hi^c.valueHi)&c.constHi) == 0 && ((lo^c.valueLo)&c.constLo) == 0
+}
+
+// overlaps returns true iff some UUID could match both layouts.
+func (c *v8Compiled) overlaps(other *v8Compiled) bool {
+	hi := (c.valueHi ^ other.valueHi) & c.constHi & other.constHi
+	lo := (c.valueLo ^ other.valueLo) & c.constLo & other.constLo
+	return hi == 0 && lo == 0
+}
+
+func (c *v8Compiled) fieldIndex(name string) int {
+	for index, field := range c.layout.Fields {
+		if field.Name == name {
+			return index
+		}
+	}
+	return -1
+}
+
+func (c *v8Compiled) checkValue(field V8Field, value uint64) error {
+	if value > widthMask(field.Width) {
+		return ErrV8LayoutNotValid{Layout: c.layout.Name, Field: field.Name, Message: fmt.Sprintf("value %d does not fit in %d bits", value, field.Width)}
+	}
+	return nil
+}
+
+func (c *v8Compiled) encode(values map[string]uint64) (UUID, error) {
+	for name := range values {
+		index := c.fieldIndex(name)
+		if index < 0 {
+			return Nil, ErrV8LayoutNotValid{Layout: c.layout.Name, Field: name, Message: "no such field"}
+		}
+		if c.layout.Fields[index].Kind == V8FieldConstant && values[name] != c.layout.Fields[index].Value {
+			return Nil, ErrV8LayoutNotValid{Layout: c.layout.Name, Field: name, Message: "value does not match constant field"}
+		}
+	}
+
+	hi, lo := c.valueHi, c.valueLo
+	for _, field := range c.layout.Fields {
+		if field.Kind == V8FieldConstant {
+			continue
+		}
+		value := values[field.Name]
+		if err := c.checkValue(field, value); err != nil {
+			return Nil, err
+		}
+		hi, lo = putBits128(hi, lo, field.Offset, field.Width, value)
+	}
+
+	var uuid UUID
+	uuid.putUint128(hi, lo)
+	uuid[6] = (uuid[6] & 0x0f) | 0x80
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return uuid, nil
+}
+
+func (c *v8Compiled) decode(result *Decoded, uuid UUID) {
+	hi, lo := uuid.uint128()
+	result.Layout = c.layout.Name
+	result.Fields = make(map[string]uint64, len(c.layout.Fields))
+	for index, field := range c.layout.Fields {
+		value := getBits128(hi, lo, field.Offset, field.Width)
+		result.Fields[field.Name] = value
+		switch index {
+		case c.timestamp:
+			result.HasTicks = true
+			result.Ticks = int64(value)
+			result.Time = v8TimeFromValue(field.Epoch, field.Unit, value)
+		case c.counter:
+			result.HasCounter = true
+			result.Counter = int(value)
+		}
+	}
+}
+
+func (c *v8Compiled) counterMax() uint64 {
+	return widthMask(c.layout.Fields[c.counter].Width)
+}
+
+// timeFromValue returns the time at the start of the given timestamp tick, or
+// the zero time if the layout has no timestamp field.
+func (c *v8Compiled) timeFromValue(value uint64) time.Time {
+	if c.timestamp < 0 {
+		return time.Time{}
+	}
+	field := c.layout.Fields[c.timestamp]
+	return v8TimeFromValue(field.Epoch, field.Unit, value).UTC()
+}
+
+func (c *v8Compiled) timestampValue(t time.Time) (uint64, error) {
+	field := c.layout.Fields[c.timestamp]
+	s := t.Unix() - field.Epoch.Unix()
+	ns := int64(t.Nanosecond()) - int64(field.Epoch.Nanosecond())
+	s, ns = normalizeSecondsAndNanos(s, ns)
+	if s < 0 {
+		return 0, ErrV8LayoutNotValid{Layout: c.layout.Name, Field: field.Name, Message: fmt.Sprintf("time %v is before the epoch", t)}
+	}
+
+	var value uint64
+	if field.Unit < time.Second {
+		perSecond := uint64(time.Second / field.Unit)
+		value = uint64(s)*perSecond + uint64(ns)/uint64(field.Unit)
+	} else {
+		value = uint64(s) / uint64(field.Unit/time.Second)
+	}
+	if value > widthMask(field.Width) {
+		return 0, ErrV8LayoutNotValid{Layout: c.layout.Name, Field: field.Name, Message: fmt.Sprintf("time %v does not fit in %d bits", t, field.Width)}
+	}
+	return value, nil
+}
+
+func v8TimeFromValue(epoch time.Time, unit time.Duration, value uint64) time.Time {
+	if unit < time.Second {
+		perSecond := uint64(time.Second / unit)
+		s := int64(value / perSecond)
+		ns := int64(value%perSecond) * int64(unit)
+		return time.Unix(epoch.Unix()+s, int64(epoch.Nanosecond())+ns)
+	}
+	s := int64(value) * int64(unit/time.Second)
+	return time.Unix(epoch.Unix()+s, int64(epoch.Nanosecond()))
+}
+
+func widthMask(width uint) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+	return (uint64(1) << width) - 1
+}
+
+// bitMask128 returns a 128-bit mask of width bits starting at offset, where
+// offset 0 is the most significant bit.
+func bitMask128(offset uint, width uint) (uint64, uint64) {
+	return shl128(0, widthMask(width), 128-offset-width)
+}
+
+func getBits128(hi uint64, lo uint64, offset uint, width uint) uint64 {
+	shift := 128 - offset - width
+	var value uint64
+	switch {
+	case shift >= 64:
+		value = hi >> (shift - 64)
+	case shift == 0:
+		value = lo
+	default:
+		value = (lo >> shift) | (hi << (64 - shift))
+	}
+	return value & widthMask(width)
+}
+
+func putBits128(hi uint64, lo uint64, offset uint, width uint, value uint64) (uint64, uint64) {
+	mh, ml := bitMask128(offset, width)
+	vh, vl := shl128(0, value&widthMask(width), 128-offset-width)
+	return (hi &^ mh) | vh, (lo &^ ml) | vl
+}
+
+func shl128(hi uint64, lo uint64, n uint) (uint64, uint64) {
+	switch {
+	case n == 0:
+		return hi, lo
+	case n >= 128:
+		return 0, 0
+	case n >= 64:
+		return lo << (n - 64), 0
+	default:
+		return (hi << n) | (lo >> (64 - n)), lo << n
+	}
+}
@@ -0,0 +1,177 @@
+package youyouayedee
+
+import (
+	"testing"
+	"time"
+)
+
+func testV8Layout(name string, tag uint64) V8Layout {
+	return V8Layout{
+		Name: name,
+		Fields: []V8Field{
+			{Name: "time", Kind: V8FieldTimestamp, Offset: 0, Width: 48},
+			{Name: "tag", Kind: V8FieldConstant, Offset: 52, Width: 4, Value: tag},
+			{Name: "seq", Kind: V8FieldCounter, Offset: 56, Width: 8},
+			{Name: "shard", Kind: V8FieldValue, Offset: 66, Width: 10},
+			{Name: "rand", Kind: V8FieldRandom, Offset: 76, Width: 52},
+		},
+	}
+}
+
+func TestV8Registry(t *testing.T) {
+	var r V8Registry
+	compareError(t, "Register/a", nil, r.Register(testV8Layout("a", 0xa)))
+	compareError(t, "Register/b", nil, r.Register(testV8Layout("b", 0xb)))
+
+	compareError(t, "Register/dup", ErrV8LayoutNotValid{Layout: "a", Message: "a layout with this name is already registered"}, r.Register(testV8Layout("a", 0xc)))
+	compareError(t, "Register/ambiguous", ErrV8LayoutAmbiguous{Layout: "c", Existing: "a"}, r.Register(testV8Layout("c", 0xa)))
+
+	untagged := V8Layout{Name: "untagged", Fields: []V8Field{{Name: "x", Kind: V8FieldRandom, Offset: 0, Width: 48}}}
+	compareError(t, "Register/untagged", ErrV8LayoutAmbiguous{Layout: "untagged", Existing: "a"}, r.Register(untagged))
+
+	wider := V8Layout{Name: "wider", Fields: []V8Field{{Name: "tag", Kind: V8FieldConstant, Offset: 52, Width: 2, Value: 0x2}}}
+	compareError(t, "Register/wider", ErrV8LayoutAmbiguous{Layout: "wider", Existing: "a"}, r.Register(wider))
+
+	uuid, err := testV8Layout("b", 0xb).Encode(map[string]uint64{"time": 1, "shard": 2})
+	compareError(t, "Encode", nil, err)
+	compare[string](t, "Encode", "00000000-0001-8b00-8020-000000000000", uuid.String())
+
+	layout, found := r.Lookup(uuid)
+	compare[bool](t, "Lookup", true, found)
+	compare[string](t, "Lookup", "b", layout.Name)
+	compare[string](t, "Decode", "b", r.Decode(uuid).Layout)
+
+	compare[bool](t, "Unregister", true, r.Unregister("b"))
+	compare[bool](t, "Unregister", false, r.Unregister("b"))
+	_, found = r.Lookup(uuid)
+	compare[bool](t, "Lookup", false, found)
+}
+
+func TestV8Layout_Invalid(t *testing.T) {
+	type testRow struct {
+		Name   string
+		Fields []V8Field
+		Field  string
+	}
+
+	testData := [...]testRow{
+		{Name: "version-bits", Fields: []V8Field{{Name: "x", Kind: V8FieldRandom, Offset: 40, Width: 10}}, Field: "x"},
+		{Name: "variant-bits", Fields: []V8Field{{Name: "x", Kind: V8FieldRandom, Offset: 60, Width: 5}}, Field: "x"},
+		{Name: "overlap", Fields: []V8Field{{Name: "x", Kind: V8FieldRandom, Offset: 0, Width: 8}, {Name: "y", Kind: V8FieldRandom, Offset: 4, Width: 8}}, Field: "y"},
+		{Name: "too-wide", Fields: []V8Field{{Name: "x", Kind: V8FieldRandom, Offset: 66, Width: 65}}, Field: "x"},
+		{Name: "past-end", Fields: []V8Field{{Name: "x", Kind: V8FieldRandom, Offset: 120, Width: 9}}, Field: "x"},
+		{Name: "constant-range", Fields: []V8Field{{Name: "x", Kind: V8FieldConstant, Offset: 0, Width: 4, Value: 16}}, Field: "x"},
+		{Name: "two-counters", Fields: []V8Field{{Name: "x", Kind: V8FieldCounter, Offset: 0, Width: 4}, {Name: "y", Kind: V8FieldCounter, Offset: 4, Width: 4}}, Field: "y"},
+		{Name: "wide-counter", Fields: []V8Field{{Name: "x", Kind: V8FieldCounter, Offset: 0, Width: 33}}, Field: "x"},
+		{Name: "bad-unit", Fields: []V8Field{{Name: "x", Kind: V8FieldTimestamp, Offset: 0, Width: 48, Unit: 3 * time.Millisecond / 7}}, Field: "x"},
+		{Name: "no-kind", Fields: []V8Field{{Name: "x", Offset: 0, Width: 4}}, Field: "x"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			err := V8Layout{Name: row.Name, Fields: row.Fields}.Validate()
+			invalid, ok := err.(ErrV8LayoutNotValid)
+			if !ok {
+				t.Errorf("Validate: expected ErrV8LayoutNotValid, got %#v", err)
+				return
+			}
+			compare[string](t, "Field", row.Field, invalid.Field)
+		})
+	}
+}
+
+func TestV8Layout_DecodeEncode(t *testing.T) {
+	layout := testV8Layout("test-orders", 0x5)
+
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	g, err := NewV8Generator(layout, map[string]uint64{"shard": 42}, Options{
+		TimeSource:      func() time.Time { return now },
+		ForceRandomNode: true,
+	})
+	compareError(t, "NewV8Generator", nil, err)
+
+	var prev UUID
+	for index := 0; index < 256; index++ {
+		uuid, err := g.NewUUID()
+		compareError(t, "NewUUID", nil, err)
+		compare[Version](t, "Version", 8, uuid.Version())
+		compare[bool](t, "IsValid", true, uuid.IsValid())
+
+		compare[string](t, "UUID.Decode", "", uuid.Decode(nil).Layout)
+
+		decoded, ok := layout.Decode(uuid)
+		compare[bool](t, "Decode", true, ok)
+		compare[string](t, "Layout", layout.Name, decoded.Layout)
+		compare[uint64](t, "shard", 42, decoded.Fields["shard"])
+		compare[uint64](t, "tag", 5, decoded.Fields["tag"])
+		compare[int](t, "Counter", index, decoded.Counter)
+		compare[time.Time](t, "Time", now, decoded.Time.UTC())
+
+		if index > 0 && string(prev[:]) >= string(uuid[:]) {
+			t.Errorf("NewUUID: %v is not after %v", uuid, prev)
+		}
+		prev = uuid
+
+		rebuilt, err := layout.Encode(decoded.Fields)
+		compareError(t, "Encode", nil, err)
+		compare[UUID](t, "Encode", uuid, rebuilt)
+
+		rebuilt, err = decoded.Encode(nil)
+		compareError(t, "Decoded.Encode", nil, err)
+		compare[UUID](t, "Decoded.Encode", uuid, rebuilt)
+	}
+
+	_, err = g.NewUUID()
+	compareError(t, "NewUUID/overflow", ErrSequenceOverflow{Layout: layout.Name, Time: now}, err)
+
+	now = now.Add(time.Millisecond)
+	uuid, err := g.NewUUID()
+	compareError(t, "NewUUID/next", nil, err)
+	decoded, _ := layout.Decode(uuid)
+	compare[int](t, "Counter", 0, decoded.Counter)
+	compare[time.Time](t, "Time", now, decoded.Time.UTC())
+
+	other, err := testV8Layout("other", 0x6).Encode(nil)
+	compareError(t, "Encode/other", nil, err)
+	_, ok := layout.Decode(other)
+	compare[bool](t, "Decode/other", false, ok)
+
+	_, err = NewV8Generator(layout, map[string]uint64{"shard": 1024}, Options{})
+	compareError(t, "NewV8Generator/range", ErrV8LayoutNotValid{Layout: layout.Name, Field: "shard", Message: "value 1024 does not fit in 10 bits"}, err)
+
+	_, err = NewV8Generator(layout, map[string]uint64{"tag": 5}, Options{})
+	compareError(t, "NewV8Generator/unknown", ErrV8LayoutNotValid{Layout: layout.Name, Field: "tag", Message: "no such value field"}, err)
+}
+
+func TestNewV8Generator_ClockStorage(t *testing.T) {
+	layout, err := NewV8Layout("test-restart",
+		V8Field{Name: "time", Kind: V8FieldTimestamp, Offset: 0, Width: 48},
+		V8Field{Name: "seq", Kind: V8FieldCounter, Offset: 66, Width: 4},
+	)
+	compareError(t, "NewV8Layout", nil, err)
+
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	o := Options{
+		TimeSource:      func() time.Time { return now },
+		ClockStorage:    &testClockStorage{},
+		ForceRandomNode: true,
+	}
+
+	g, err := NewV8Generator(layout, nil, o)
+	compareError(t, "NewV8Generator", nil, err)
+	for index := 0; index < 3; index++ {
+		_, err = g.NewUUID()
+		compareError(t, "NewUUID", nil, err)
+	}
+
+	// A restarted generator continues from the saved counter, even if the
+	// clock has moved backward.
+	now = now.Add(-time.Second)
+	g, err = NewV8Generator(layout, nil, o)
+	compareError(t, "NewV8Generator/restart", nil, err)
+	uuid, err := g.NewUUID()
+	compareError(t, "NewUUID/restart", nil, err)
+	decoded, _ := layout.Decode(uuid)
+	compare[int](t, "Counter", 3, decoded.Counter)
+	compare[time.Time](t, "Time", now.Add(time.Second), decoded.Time.UTC())
+}
@@ -0,0 +1,143 @@
+package youyouayedee
+
+import (
+	"time"
+)
+
+// DefaultMaxFutureSkew is the default value of Validator.MaxFutureSkew.
+const DefaultMaxFutureSkew = 5 * time.Minute
+
+// Validator checks UUIDs against a configurable set of semantic rules, beyond
+// the variant check performed by UUID.IsValid.  It is intended for checking
+// UUIDs received as API input.
+//
+// The zero value checks all rules with the default settings.
+//
+// Note that RFC 4122 requires randomly generated node identifiers to have the
+// multicast bit set, so RuleUnicastNode rejects V1 and V6 UUIDs from hosts
+// without a usable hardware address.  Omit it from Rules if such UUIDs must be
+// accepted.
+//
+type Validator struct {
+	// Rules lists the rules to check.  If this field is empty, then all
+	// rules are checked.  Rules are always checked in the order in which
+	// they are declared, regardless of their order in this list.
+	//
+	// RuleAllowedVersion has no effect unless Versions is also set.
+	//
+	Rules []ValidationRule
+
+	// Versions lists the UUID versions allowed by RuleAllowedVersion.
+	Versions []Version
+
+	// MaxFutureSkew is how far in the future the timestamp of a V1, V6,
+	// or V7 UUID may be before RuleTimeNotInFuture rejects it.  If zero,
+	// then DefaultMaxFutureSkew is used.
+	MaxFutureSkew time.Duration
+
+	// TimeSource returns the current time.  If nil, then time.Now is
+	// used.
+	TimeSource func() time.Time
+
+	// LeapSecondCalculator is used to decode the timestamps of V1 and V6
+	// UUIDs.  If nil, then LeapSecondCalculatorDummy is used.
+	LeapSecondCalculator LeapSecondCalculator
+}
+
+// Validate checks the given UUID using the default Validator.
+func Validate(uuid UUID) error {
+	return Validator{}.Validate(uuid)
+}
+
+// Validate checks the given UUID against the configured rules.  The returned
+// error, if any, is an ErrValidationFailed naming the first rule that failed.
+//
+// RuleRFC4122Variant is checked first after RuleNotNilOrMax; if it is
+// disabled, then UUIDs of other variants pass all the remaining rules, which
+// only apply to RFC 4122 variant UUIDs.
+//
+func (v Validator) Validate(uuid UUID) error {
+	if v.checks(RuleNotNilOrMax) {
+		if uuid.IsZero() {
+			return v.fail(uuid, RuleNotNilOrMax, "nil")
+		}
+		if uuid.IsMax() {
+			return v.fail(uuid, RuleNotNilOrMax, "max")
+		}
+	}
+
+	variant := uuid.Variant()
+	if variant != VariantRFC4122 {
+		if v.checks(RuleRFC4122Variant) {
+			return v.fail(uuid, RuleRFC4122Variant, variant)
+		}
+		return nil
+	}
+
+	version := uuid.Version()
+	if v.checks(RuleKnownVersion) && !version.IsValid() {
+		return v.fail(uuid, RuleKnownVersion, uint(version))
+	}
+
+	if v.checks(RuleAllowedVersion) && len(v.Versions) != 0 {
+		found := false
+		for _, allowed := range v.Versions {
+			if allowed == version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return v.fail(uuid, RuleAllowedVersion, uint(version), v.Versions)
+		}
+	}
+
+	decoded := uuid.Decode(v.LeapSecondCalculator)
+
+	if v.checks(RuleTimeNotInFuture) && (version == 1 || version == 6 || version == 7) {
+		now := v.TimeSource
+		if now == nil {
+			now = time.Now
+		}
+
+		skew := v.MaxFutureSkew
+		if skew == 0 {
+			skew = DefaultMaxFutureSkew
+		}
+
+		if decoded.Time.After(now().Add(skew)) {
+			return v.fail(uuid, RuleTimeNotInFuture, decoded.Time.UTC(), skew)
+		}
+	}
+
+	if v.checks(RuleUnicastNode) && decoded.HasNode {
+		if decoded.Node.IsZero() {
+			return v.fail(uuid, RuleUnicastNode, decoded.Node, "nil")
+		}
+		if decoded.Node.IsMulticast() {
+			return v.fail(uuid, RuleUnicastNode, decoded.Node, "multicast")
+		}
+	}
+
+	if v.checks(RuleKnownDomain) && decoded.HasDomainAndID && !decoded.Domain.IsValid() {
+		return v.fail(uuid, RuleKnownDomain, byte(decoded.Domain))
+	}
+
+	return nil
+}
+
+func (v Validator) checks(rule ValidationRule) bool {
+	if len(v.Rules) == 0 {
+		return true
+	}
+	for _, enabled := range v.Rules {
+		if enabled == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func (v Validator) fail(uuid UUID, rule ValidationRule, args ...interface{}) error {
+	return ErrValidationFailed{UUID: uuid, Rule: rule, Args: args}
+}
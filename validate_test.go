@@ -0,0 +1,77 @@
+package youyouayedee
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidator(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	timeSource := func() time.Time { return now }
+
+	makeV7 := func(t time.Time) UUID {
+		var uuid UUID
+		putUint48(uuid[0:6], uint64(t.UnixMilli()))
+		uuid[6] = 0x70
+		uuid[8] = 0x80
+		return uuid
+	}
+
+	type testRow struct {
+		Name      string
+		Validator Validator
+		UUID      UUID
+		Rule      ValidationRule
+	}
+
+	v1Unicast := Must(Parse("d3ef7600-6a95-11ec-9234-2258840c40e6"))
+	v1Nil := Must(Parse("d3ef7600-6a95-11ec-9234-000000000000"))
+	v2Bad := Must(Parse("000003e8-6a95-21ec-9205-2258840c40e6"))
+	v2Good := Must(Parse("000003e8-6a95-21ec-9201-2258840c40e6"))
+	v0 := Must(Parse("d3ef7600-6a95-01ec-9234-2258840c40e6"))
+	v9 := Must(Parse("d3ef7600-6a95-91ec-9234-2258840c40e6"))
+	ncs := Must(ParseOptions{AcceptAnyVariant: true}.Parse("333a2276-0000-0000-0d00-00809c000000"))
+
+	def := Validator{TimeSource: timeSource}
+
+	testData := [...]testRow{
+		{Name: "v1-unicast", Validator: def, UUID: v1Unicast},
+		{Name: "v1-multicast", Validator: def, UUID: uuidV1, Rule: RuleUnicastNode},
+		{Name: "v1-multicast-allowed", Validator: Validator{TimeSource: timeSource, Rules: []ValidationRule{RuleTimeNotInFuture}}, UUID: uuidV1},
+		{Name: "v1-nil-node", Validator: def, UUID: v1Nil, Rule: RuleUnicastNode},
+		{Name: "v2-good", Validator: def, UUID: v2Good},
+		{Name: "v2-bad-domain", Validator: def, UUID: v2Bad, Rule: RuleKnownDomain},
+		{Name: "v0", Validator: def, UUID: v0, Rule: RuleKnownVersion},
+		{Name: "v9", Validator: def, UUID: v9, Rule: RuleKnownVersion},
+		{Name: "nil", Validator: def, UUID: Nil, Rule: RuleNotNilOrMax},
+		{Name: "max", Validator: def, UUID: Max, Rule: RuleNotNilOrMax},
+		{Name: "ncs", Validator: def, UUID: ncs, Rule: RuleRFC4122Variant},
+		{Name: "ncs-allowed", Validator: Validator{Rules: []ValidationRule{RuleKnownVersion}}, UUID: ncs},
+		{Name: "v7-now", Validator: def, UUID: makeV7(now)},
+		{Name: "v7-skew", Validator: def, UUID: makeV7(now.Add(4 * time.Minute))},
+		{Name: "v7-future", Validator: def, UUID: makeV7(now.Add(time.Hour)), Rule: RuleTimeNotInFuture},
+		{Name: "v7-future-skew", Validator: Validator{TimeSource: timeSource, MaxFutureSkew: 2 * time.Hour}, UUID: makeV7(now.Add(time.Hour))},
+		{Name: "v7-wrong-version", Validator: Validator{TimeSource: timeSource, Versions: []Version{4}}, UUID: makeV7(now), Rule: RuleAllowedVersion},
+		{Name: "v7-right-version", Validator: Validator{TimeSource: timeSource, Versions: []Version{4, 7}}, UUID: makeV7(now)},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			err := row.Validator.Validate(row.UUID)
+			if row.Rule == 0 {
+				compareError(t, "Validate", nil, err)
+				return
+			}
+			failed, ok := err.(ErrValidationFailed)
+			if !ok {
+				t.Errorf("Validate: expected ErrValidationFailed, got %#v", err)
+				return
+			}
+			compare[ValidationRule](t, "Rule", row.Rule, failed.Rule)
+			compare[UUID](t, "UUID", row.UUID, failed.UUID)
+		})
+	}
+
+	err := def.Validate(makeV7(now.Add(time.Hour)))
+	compare[string](t, "Error", `UUID 017e1326-8a80-7000-8000-000000000000 failed validation rule "time not in future": UUID timestamp 2022-01-01 01:00:00 +0000 UTC is more than 5m0s in the future`, err.Error())
+}